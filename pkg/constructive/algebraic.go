@@ -0,0 +1,1004 @@
+package constructive
+
+import (
+	"math/big"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// maxAlgebraicPrimes bounds the number of distinct primes under square roots
+// that AlgebraicDegree will analyze, since the analysis enumerates all 2^k
+// conjugates of a number involving k such primes.
+const maxAlgebraicPrimes = 12
+
+// AlgebraicDegree computes the degree of c as an algebraic number, that is,
+// the degree of its minimal polynomial over the rationals, by inspecting its
+// construction. For example, a rational has degree 1, Sqrt2() and Phi() have
+// degree 2, and Add(Sqrt2(), Sqrt(FromInt(3))) has degree 4.
+//
+// Nested square roots and n-th roots, such as Sqrt(Sqrt2()) of degree 4 and
+// NthRoot(Two(), 3) of degree 3, are analyzed by a slower, general method.
+//
+// It returns false when c is not built only from integers, rationals, the
+// arithmetic operations, and roots; in particular, when c involves a
+// transcendental function. This does not imply that c is transcendental.
+func AlgebraicDegree(c Real) (int, bool) {
+	if e, ok := multiquadratic(c); ok {
+		if conjs, ok := e.conjugates(); ok {
+			return len(conjs), true
+		}
+	}
+
+	p, ok := generalMinimal(c)
+	if !ok {
+		return 0, false
+	}
+	return p.degree(), true
+}
+
+// MinimalPolynomial computes the minimal polynomial of c over the rationals,
+// for constructions that AlgebraicDegree can analyze, such as a + b√d. The
+// polynomial is monic, and its coefficients are ordered as for PolyEval, so
+// that coeffs[i] is the coefficient of x^i; for Phi(), which is a root of
+// x² - x - 1, the coefficients are -1, -1, and 1.
+func MinimalPolynomial(c Real) ([]*big.Rat, bool) {
+	e, ok := multiquadratic(c)
+	if !ok {
+		return generalMinimal(c)
+	}
+
+	conjs, ok := e.conjugates()
+	if !ok {
+		return generalMinimal(c)
+	}
+
+	// multiply out ∏ (x - σ(c)) over the distinct conjugates σ(c)
+	poly := []mqElement{mqRational(big.NewRat(1, 1))}
+	for _, conj := range conjs {
+		next := make([]mqElement, len(poly)+1)
+		next[0] = mqElement{}
+		for i, p := range poly {
+			next[i+1] = p
+			next[i] = next[i].add(p.mul(conj.neg()))
+		}
+		poly = next
+	}
+
+	coeffs := make([]*big.Rat, len(poly))
+	for i, p := range poly {
+		if coeffs[i], ok = p.rational(); !ok {
+			return nil, false
+		}
+	}
+	return coeffs, true
+}
+
+// surd is the term q·√m, where m is the product of distinct primes.
+type surd struct {
+	primes []*big.Int
+	q      *big.Rat
+}
+
+// mqElement is an element of a multiquadratic field, that is, a rational
+// linear combination of square roots of squarefree integers, keyed by the
+// primes under the root. The field is closed under the arithmetic operations,
+// and a term with the key "" is rational.
+type mqElement map[string]surd
+
+func surdKey(primes []*big.Int) string {
+	parts := make([]string, len(primes))
+	for i, p := range primes {
+		parts[i] = p.String()
+	}
+	return strings.Join(parts, ",")
+}
+
+func mqRational(r *big.Rat) mqElement {
+	e := mqElement{}
+	e.addTerm(nil, r)
+	return e
+}
+
+// addTerm adds q·√(∏primes) to e in place, dropping terms that cancel.
+func (e mqElement) addTerm(primes []*big.Int, q *big.Rat) {
+	key := surdKey(primes)
+	if t, ok := e[key]; ok {
+		q = new(big.Rat).Add(t.q, q)
+	}
+
+	if q.Sign() == 0 {
+		delete(e, key)
+		return
+	}
+	e[key] = surd{primes: primes, q: q}
+}
+
+func (e mqElement) add(o mqElement) mqElement {
+	r := mqElement{}
+	for _, t := range e {
+		r.addTerm(t.primes, t.q)
+	}
+	for _, t := range o {
+		r.addTerm(t.primes, t.q)
+	}
+	return r
+}
+
+func (e mqElement) neg() mqElement {
+	r := mqElement{}
+	for _, t := range e {
+		r.addTerm(t.primes, new(big.Rat).Neg(t.q))
+	}
+	return r
+}
+
+// mul multiplies e by o, using √(∏P) √(∏Q) = ∏(P ∩ Q) √(∏(P Δ Q)).
+func (e mqElement) mul(o mqElement) mqElement {
+	r := mqElement{}
+	for _, a := range e {
+		for _, b := range o {
+			q := new(big.Rat).Mul(a.q, b.q)
+			var primes []*big.Int
+			i, j := 0, 0
+			for i < len(a.primes) || j < len(b.primes) {
+				switch {
+				case j == len(b.primes) || i < len(a.primes) && a.primes[i].Cmp(b.primes[j]) < 0:
+					primes = append(primes, a.primes[i])
+					i++
+				case i == len(a.primes) || a.primes[i].Cmp(b.primes[j]) > 0:
+					primes = append(primes, b.primes[j])
+					j++
+				default:
+					q.Mul(q, new(big.Rat).SetInt(a.primes[i]))
+					i++
+					j++
+				}
+			}
+			r.addTerm(primes, q)
+		}
+	}
+	return r
+}
+
+// rational returns the value of e if it is rational.
+func (e mqElement) rational() (*big.Rat, bool) {
+	switch len(e) {
+	case 0:
+		return new(big.Rat), true
+	case 1:
+		if t, ok := e[""]; ok {
+			return new(big.Rat).Set(t.q), true
+		}
+	}
+	return nil, false
+}
+
+// primes returns the distinct primes under the roots of e, in ascending order.
+func (e mqElement) primes() []*big.Int {
+	var ps []*big.Int
+	for _, t := range e {
+		for _, p := range t.primes {
+			if !slices.ContainsFunc(ps, func(q *big.Int) bool { return q.Cmp(p) == 0 }) {
+				ps = append(ps, p)
+			}
+		}
+	}
+
+	sort.Slice(ps, func(i, j int) bool { return ps[i].Cmp(ps[j]) < 0 })
+	return ps
+}
+
+// conjugate applies the field automorphism that maps √p to -√p for each
+// prime p in flip, and fixes the other primes.
+func (e mqElement) conjugate(flip []*big.Int) mqElement {
+	r := mqElement{}
+	for _, t := range e {
+		q := t.q
+		for _, p := range t.primes {
+			if slices.ContainsFunc(flip, func(f *big.Int) bool { return f.Cmp(p) == 0 }) {
+				q = new(big.Rat).Neg(q)
+			}
+		}
+		r.addTerm(t.primes, q)
+	}
+	return r
+}
+
+// conjugates returns the distinct conjugates of e, starting with e itself;
+// their number is the degree of e. It returns false if e involves too many
+// primes to enumerate.
+func (e mqElement) conjugates() ([]mqElement, bool) {
+	ps := e.primes()
+	if len(ps) > maxAlgebraicPrimes {
+		return nil, false
+	}
+
+	seen := map[string]bool{}
+	var conjs []mqElement
+	for mask := 0; mask < 1<<len(ps); mask++ {
+		var flip []*big.Int
+		for i, p := range ps {
+			if mask&(1<<i) != 0 {
+				flip = append(flip, p)
+			}
+		}
+
+		c := e.conjugate(flip)
+		if key := c.String(); !seen[key] {
+			seen[key] = true
+			conjs = append(conjs, c)
+		}
+	}
+	return conjs, true
+}
+
+// inverse computes 1/e by multiplying e by its conjugates over each prime in
+// turn, which eliminates that prime, until only the rational norm remains.
+func (e mqElement) inverse() (mqElement, bool) {
+	num := mqRational(big.NewRat(1, 1))
+	den := e
+	for _, p := range e.primes() {
+		c := den.conjugate([]*big.Int{p})
+		num = num.mul(c)
+		den = den.mul(c)
+	}
+
+	r, ok := den.rational()
+	if !ok || r.Sign() == 0 {
+		return nil, false
+	}
+	return num.mul(mqRational(r.Inv(r))), true
+}
+
+// sqrt computes the square root of e, which must be a non-negative rational.
+func (e mqElement) sqrt() (mqElement, bool) {
+	r, ok := e.rational()
+	if !ok || r.Sign() < 0 {
+		return nil, false
+	}
+	if r.Sign() == 0 {
+		return mqElement{}, true
+	}
+
+	// √(a/b) = √(ab)/b, with ab = s²m for squarefree m
+	s, primes, ok := squarefree(new(big.Int).Mul(r.Num(), r.Denom()))
+	if !ok {
+		return nil, false
+	}
+
+	res := mqElement{}
+	res.addTerm(primes, new(big.Rat).SetFrac(s, r.Denom()))
+	return res, true
+}
+
+func (e mqElement) String() string {
+	keys := make([]string, 0, len(e))
+	for k := range e {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	sb := &strings.Builder{}
+	for _, k := range keys {
+		sb.WriteString(e[k].q.RatString() + "√[" + k + "] ")
+	}
+	return sb.String()
+}
+
+// squarefreeTrialLimit bounds the primes found by trial division in
+// squarefree; a larger cofactor must be a prime or a perfect square.
+const squarefreeTrialLimit = 1 << 16
+
+// squarefree decomposes n > 0 as s²·∏primes, where primes are distinct and in
+// ascending order. It returns false when n cannot be factored completely.
+func squarefree(n *big.Int) (*big.Int, []*big.Int, bool) {
+	n = new(big.Int).Set(n)
+	s := big.NewInt(1)
+	var primes []*big.Int
+
+	rem := new(big.Int)
+	for d := int64(2); d < squarefreeTrialLimit && n.Cmp(big.NewInt(d*d)) >= 0; d++ {
+		bd := big.NewInt(d)
+		odd := false
+		for {
+			q, r := new(big.Int).QuoRem(n, bd, rem)
+			if r.Sign() != 0 {
+				break
+			}
+			n = q
+			if odd {
+				s.Mul(s, bd)
+			}
+			odd = !odd
+		}
+		if odd {
+			primes = append(primes, bd)
+		}
+	}
+
+	switch root := new(big.Int).Sqrt(n); {
+	case n.Cmp(big.NewInt(1)) == 0:
+	case new(big.Int).Mul(root, root).Cmp(n) == 0:
+		s.Mul(s, root)
+	case n.ProbablyPrime(20):
+		primes = append(primes, n)
+	default:
+		return nil, nil, false
+	}
+
+	return s, primes, true
+}
+
+// multiquadratic computes the value of c as an element of a multiquadratic
+// field, if c is constructed from rationals using the arithmetic operations
+// and square roots of rationals.
+func multiquadratic(c Real) (mqElement, bool) {
+	switch v := Unwrap(c).(type) {
+	case *constructiveInteger:
+		return mqRational(new(big.Rat).SetInt(v.i)), true
+	case *constructiveRational:
+		return mqRational(v.Rat()), true
+	case *constructiveAddition:
+		a, ok := multiquadratic(v.a)
+		if !ok {
+			return nil, false
+		}
+		b, ok := multiquadratic(v.b)
+		if !ok {
+			return nil, false
+		}
+		return a.add(b), true
+	case *constructiveMultiplication:
+		a, ok := multiquadratic(v.a)
+		if !ok {
+			return nil, false
+		}
+		b, ok := multiquadratic(v.b)
+		if !ok {
+			return nil, false
+		}
+		return a.mul(b), true
+	case *constructiveMultiplicativeInverse:
+		r, ok := multiquadratic(v.r)
+		if !ok {
+			return nil, false
+		}
+		return r.inverse()
+	case *constructiveShift:
+		r, ok := multiquadratic(v.r)
+		if !ok {
+			return nil, false
+		}
+		f := new(big.Rat).SetInt(bigLsh(big.NewInt(1), uint(max(v.n, -v.n))))
+		if v.n < 0 {
+			f.Inv(f)
+		}
+		return r.mul(mqRational(f)), true
+	case *constructiveNegation:
+		r, ok := multiquadratic(v.r)
+		if !ok {
+			return nil, false
+		}
+		return r.neg(), true
+	case *constructiveCondsign:
+		r, ok := multiquadratic(v.r)
+		if !ok {
+			return nil, false
+		}
+		a, aok := multiquadratic(v.a)
+		b, bok := multiquadratic(v.b)
+		if len(r) == 0 {
+			if aok && bok && a.String() == b.String() {
+				return a, true
+			}
+			return nil, false
+		}
+
+		// r is exactly nonzero, so its sign can be decided
+		if Sign(v.r) < 0 {
+			return a, aok
+		}
+		return b, bok
+	case *prescaledSqrt:
+		r, ok := multiquadratic(v.r)
+		if !ok {
+			return nil, false
+		}
+		return r.sqrt()
+	}
+
+	return nil, false
+}
+
+// maxAnnihilatorDegree bounds the degree of the polynomials that annihilator
+// works with, since their number of coefficients, and the size of the
+// lattices searched for the minimal polynomial, grow with it.
+const maxAnnihilatorDegree = 48
+
+// ratPoly is a polynomial with rational coefficients, where p[i] is the
+// coefficient of x^i. The zero polynomial is empty.
+type ratPoly []*big.Rat
+
+func (p ratPoly) degree() int {
+	return len(p) - 1
+}
+
+// trim drops the leading zero coefficients of p.
+func (p ratPoly) trim() ratPoly {
+	for len(p) > 0 && p[len(p)-1].Sign() == 0 {
+		p = p[:len(p)-1]
+	}
+	return p
+}
+
+func (p ratPoly) add(q ratPoly) ratPoly {
+	r := make(ratPoly, max(len(p), len(q)))
+	for i := range r {
+		r[i] = new(big.Rat)
+		if i < len(p) {
+			r[i].Add(r[i], p[i])
+		}
+		if i < len(q) {
+			r[i].Add(r[i], q[i])
+		}
+	}
+	return r.trim()
+}
+
+func (p ratPoly) mul(q ratPoly) ratPoly {
+	if len(p) == 0 || len(q) == 0 {
+		return nil
+	}
+
+	r := make(ratPoly, len(p)+len(q)-1)
+	for i := range r {
+		r[i] = new(big.Rat)
+	}
+	t := new(big.Rat)
+	for i, a := range p {
+		for j, b := range q {
+			r[i+j].Add(r[i+j], t.Mul(a, b))
+		}
+	}
+	return r.trim()
+}
+
+func (p ratPoly) scale(f *big.Rat) ratPoly {
+	r := make(ratPoly, len(p))
+	for i, a := range p {
+		r[i] = new(big.Rat).Mul(a, f)
+	}
+	return r.trim()
+}
+
+// divMod divides p by a non-zero q, returning the quotient and remainder.
+func (p ratPoly) divMod(q ratPoly) (ratPoly, ratPoly) {
+	rem := make(ratPoly, len(p))
+	for i, a := range p {
+		rem[i] = new(big.Rat).Set(a)
+	}
+	if len(p) < len(q) {
+		return nil, rem
+	}
+
+	quo := make(ratPoly, len(p)-len(q)+1)
+	lc := q[len(q)-1]
+	t := new(big.Rat)
+	for i := len(quo) - 1; i >= 0; i-- {
+		f := new(big.Rat).Quo(rem[i+len(q)-1], lc)
+		quo[i] = f
+		for j, b := range q {
+			rem[i+j].Sub(rem[i+j], t.Mul(f, b))
+		}
+	}
+	return quo.trim(), rem.trim()
+}
+
+// monic divides p by its leading coefficient.
+func (p ratPoly) monic() ratPoly {
+	return p.scale(new(big.Rat).Inv(p[len(p)-1]))
+}
+
+func (p ratPoly) gcd(q ratPoly) ratPoly {
+	for len(q) > 0 {
+		_, r := p.divMod(q)
+		p, q = q, r
+	}
+	return p.monic()
+}
+
+func (p ratPoly) derivative() ratPoly {
+	if len(p) < 2 {
+		return nil
+	}
+
+	r := make(ratPoly, len(p)-1)
+	for i := range r {
+		r[i] = new(big.Rat).Mul(p[i+1], new(big.Rat).SetInt64(int64(i+1)))
+	}
+	return r.trim()
+}
+
+// squarefree removes the repeated factors of p, leaving the same roots.
+func (p ratPoly) squarefree() ratPoly {
+	q, _ := p.divMod(p.gcd(p.derivative()))
+	return q.monic()
+}
+
+// eval evaluates p at x with Horner's method.
+func (p ratPoly) eval(x *big.Rat) *big.Rat {
+	r := new(big.Rat)
+	for i := len(p) - 1; i >= 0; i-- {
+		r.Mul(r, x)
+		r.Add(r, p[i])
+	}
+	return r
+}
+
+// compose computes p(q(x)).
+func (p ratPoly) compose(q ratPoly) ratPoly {
+	var r ratPoly
+	for i := len(p) - 1; i >= 0; i-- {
+		r = r.mul(q).add(ratPoly{p[i]})
+	}
+	return r
+}
+
+// resultant computes the resultant of p and q with the Euclidean algorithm,
+// using res(p, q) = (-1)^(deg p · deg q) lc(q)^(deg p - deg r) res(q, r),
+// where r is the remainder of p divided by q.
+func (p ratPoly) resultant(q ratPoly) *big.Rat {
+	res := big.NewRat(1, 1)
+	for {
+		if len(p) == 0 || len(q) == 0 {
+			return new(big.Rat)
+		}
+		if q.degree() == 0 {
+			for i := 0; i < p.degree(); i++ {
+				res.Mul(res, q[0])
+			}
+			return res
+		}
+
+		_, r := p.divMod(q)
+		if len(r) == 0 {
+			return new(big.Rat)
+		}
+		if p.degree()%2 == 1 && q.degree()%2 == 1 {
+			res.Neg(res)
+		}
+		for i := 0; i < p.degree()-r.degree(); i++ {
+			res.Mul(res, q[len(q)-1])
+		}
+		p, q = q, r
+	}
+}
+
+// interpolate computes the polynomial of degree at most n that takes the
+// value f(x) at x = 0, 1, ..., n, using Newton's divided differences.
+func interpolate(n int, f func(x *big.Rat) *big.Rat) ratPoly {
+	coeffs := make([]*big.Rat, n+1)
+	for i := range coeffs {
+		coeffs[i] = f(new(big.Rat).SetInt64(int64(i)))
+	}
+	for j := 1; j <= n; j++ {
+		for i := n; i >= j; i-- {
+			coeffs[i].Sub(coeffs[i], coeffs[i-1])
+			coeffs[i].Quo(coeffs[i], new(big.Rat).SetInt64(int64(j)))
+		}
+	}
+
+	// expand Σ coeffs[i] x(x-1)...(x-i+1), innermost first
+	var r ratPoly
+	for i := n; i >= 0; i-- {
+		r = r.mul(ratPoly{new(big.Rat).SetInt64(int64(-i)), big.NewRat(1, 1)}).add(ratPoly{coeffs[i]})
+	}
+	return r
+}
+
+// annihilatorSum computes a polynomial whose roots include a + b for every
+// root a of p and b of q, as the resultant res_y(p(y), q(x - y)).
+func annihilatorSum(p, q ratPoly) ratPoly {
+	return interpolate(p.degree()*q.degree(), func(x *big.Rat) *big.Rat {
+		return p.resultant(q.compose(ratPoly{x, big.NewRat(-1, 1)}))
+	})
+}
+
+// annihilatorProduct computes a polynomial whose roots include ab for every
+// root a of p and b of q, as the resultant res_y(p(y), y^(deg q) q(x/y)).
+func annihilatorProduct(p, q ratPoly) ratPoly {
+	return interpolate(p.degree()*q.degree(), func(x *big.Rat) *big.Rat {
+		h := make(ratPoly, len(q))
+		xi := big.NewRat(1, 1)
+		for i, b := range q {
+			h[q.degree()-i] = new(big.Rat).Mul(b, xi)
+			xi = new(big.Rat).Mul(xi, x)
+		}
+		return p.resultant(h.trim())
+	})
+}
+
+// substitutePower computes p(x^n), whose roots are the n-th roots of those
+// of p.
+func (p ratPoly) substitutePower(n int) ratPoly {
+	r := make(ratPoly, p.degree()*n+1)
+	for i := range r {
+		r[i] = new(big.Rat)
+	}
+	for i, a := range p {
+		r[i*n].Set(a)
+	}
+	return r
+}
+
+// annihilator computes a squarefree polynomial that has c as a root, for
+// constructions from rationals, the arithmetic operations, square roots, and
+// n-th roots, memoizing the result for each node of the construction.
+type annihilator map[Real]ratPoly
+
+func (m annihilator) of(c Real) (ratPoly, bool) {
+	c = Unwrap(c)
+	if p, ok := m[c]; ok {
+		return p, p != nil
+	}
+
+	p, ok := m.walk(c)
+	if ok {
+		p = p.squarefree()
+		if p.degree() > maxAnnihilatorDegree {
+			p, ok = nil, false
+		}
+	}
+	m[c] = p
+	return p, ok
+}
+
+// nonzero reports whether c, a root of p, is certainly not zero: either zero
+// is not a root of p, in which case Sign terminates, or c can be told apart
+// from zero.
+func nonzero(c Real, p ratPoly) (int, bool) {
+	if p[0].Sign() != 0 {
+		return Sign(c), true
+	}
+
+	sign := deepSign(c)
+	return sign, sign != 0
+}
+
+func (m annihilator) walk(c Real) (ratPoly, bool) {
+	linear := func(r *big.Rat) (ratPoly, bool) {
+		return ratPoly{new(big.Rat).Neg(r), big.NewRat(1, 1)}, true
+	}
+
+	switch v := c.(type) {
+	case *constructiveInteger:
+		return linear(new(big.Rat).SetInt(v.i))
+	case *constructiveRational:
+		return linear(v.Rat())
+	case *constructiveAddition:
+		a, ok := m.of(v.a)
+		if !ok {
+			return nil, false
+		}
+		b, ok := m.of(v.b)
+		if !ok {
+			return nil, false
+		}
+		return annihilatorSum(a, b), true
+	case *constructiveMultiplication:
+		a, ok := m.of(v.a)
+		if !ok {
+			return nil, false
+		}
+		b, ok := m.of(v.b)
+		if !ok {
+			return nil, false
+		}
+		return annihilatorProduct(a, b), true
+	case *constructiveMultiplicativeInverse:
+		r, ok := m.of(v.r)
+		if !ok {
+			return nil, false
+		}
+		if _, ok := nonzero(v.r, r); !ok {
+			return nil, false
+		}
+
+		// the roots of the reversed polynomial are the inverses of the
+		// non-zero roots
+		for r[0].Sign() == 0 {
+			r = r[1:]
+		}
+		rev := make(ratPoly, len(r))
+		for i, a := range r {
+			rev[r.degree()-i] = a
+		}
+		return rev, rev.degree() > 0
+	case *constructiveShift:
+		r, ok := m.of(v.r)
+		if !ok {
+			return nil, false
+		}
+		f := new(big.Rat).SetInt(bigLsh(big.NewInt(1), uint(max(v.n, -v.n))))
+		if v.n > 0 {
+			f.Inv(f)
+		}
+		// r(x/2^n)
+		return r.compose(ratPoly{new(big.Rat), f}), true
+	case *constructiveNegation:
+		r, ok := m.of(v.r)
+		if !ok {
+			return nil, false
+		}
+		return r.compose(ratPoly{new(big.Rat), big.NewRat(-1, 1)}), true
+	case *constructiveCondsign:
+		r, ok := m.of(v.r)
+		if !ok {
+			return nil, false
+		}
+		sign, ok := nonzero(v.r, r)
+		if !ok {
+			return nil, false
+		}
+		if sign < 0 {
+			return m.of(v.a)
+		}
+		return m.of(v.b)
+	case *prescaledSqrt:
+		r, ok := m.of(v.r)
+		if !ok {
+			return nil, false
+		}
+		if sign, ok := nonzero(v.r, r); !ok || sign < 0 {
+			return nil, false
+		}
+		return r.substitutePower(2), true
+	case *nthRoot:
+		r, ok := m.of(v.r)
+		if !ok {
+			return nil, false
+		}
+		if v.n%2 == 0 {
+			if sign, ok := nonzero(v.r, r); !ok || sign < 0 {
+				return nil, false
+			}
+		}
+		return r.substitutePower(v.n), true
+	}
+
+	return nil, false
+}
+
+// generalMinimal computes the minimal polynomial of c from an annihilating
+// polynomial of its construction.
+func generalMinimal(c Real) (ratPoly, bool) {
+	p, ok := annihilator{}.of(c)
+	if !ok {
+		return nil, false
+	}
+	return generalMinimalPolynomial(c, p)
+}
+
+// integer scales p to a primitive polynomial with integer coefficients.
+func (p ratPoly) integer() []*big.Int {
+	den := big.NewInt(1)
+	for _, a := range p {
+		den = lcm(den, a.Denom())
+	}
+
+	coeffs := make([]*big.Int, len(p))
+	content := new(big.Int)
+	for i, a := range p {
+		coeffs[i] = new(big.Int).Mul(a.Num(), new(big.Int).Quo(den, a.Denom()))
+		content.GCD(nil, nil, content, bigAbs(coeffs[i]))
+	}
+	for _, a := range coeffs {
+		a.Quo(a, content)
+	}
+	return coeffs
+}
+
+// lcm computes the least common multiple of positive a and b.
+func lcm(a, b *big.Int) *big.Int {
+	g := new(big.Int).GCD(nil, nil, a, b)
+	return new(big.Int).Mul(a, new(big.Int).Quo(b, g))
+}
+
+// generalMinimalPolynomial computes the minimal polynomial of c from a
+// squarefree polynomial p that has c as a root. For each degree d in turn, an
+// integer relation among 1, c, ..., c^d is searched for with LLL, at a
+// precision at which the minimal polynomial is certain to be found if its
+// degree is at most d. A candidate is accepted when it divides p exactly and
+// the quotient does not vanish at c, which proves that c is its root.
+func generalMinimalPolynomial(c Real, p ratPoly) (ratPoly, bool) {
+	// the coefficients of a factor of p of degree d are at most 2^d ‖p‖
+	norm := new(big.Int)
+	for _, a := range p.integer() {
+		norm.Add(norm, new(big.Int).Mul(a, a))
+	}
+	normBits := (norm.BitLen() + 1) / 2
+
+	magBits := 0
+	if n, ok := MSD(c); ok && n > 0 {
+		magBits = n + 1
+	}
+
+	for d := 1; d < p.degree(); d++ {
+		heightBits := d + normBits
+		prec := d*d/2 + (3*d+4)*boundLog2(d+1) + 2*d*heightBits + d*magBits + 64
+
+		q := integerRelation(c, d, prec)
+		if q == nil {
+			continue
+		}
+		quo, rem := p.divMod(q)
+		if len(rem) != 0 {
+			continue
+		}
+
+		cs := make([]Real, len(quo))
+		for i, a := range quo {
+			cs[i] = FromRatExact(a)
+		}
+		if certainlyNonzero(PolyEval(cs, c)) {
+			return q.monic(), true
+		}
+	}
+
+	return p.monic(), true
+}
+
+// certainlyNonzero reports whether c is proven to be non-zero by one of its
+// approximations, down to the precision that the inverse searches to.
+func certainlyNonzero(c Real) bool {
+	for p := -64; p >= inverseMSDPrecision; p *= 2 {
+		if Approximate(c, p).CmpAbs(bigOne) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// integerRelation searches for integers a₀, ..., a_d, not all zero, such that
+// Σ aᵢcⁱ is close to zero, by reducing the lattice spanned by the rows
+// (eᵢ, cⁱ 2^prec) with LLL. It returns the polynomial of the shortest vector
+// found, or nil.
+func integerRelation(c Real, d, prec int) ratPoly {
+	basis := make([][]*big.Int, d+1)
+	power := One()
+	for i := range basis {
+		row := make([]*big.Int, d+2)
+		for j := range row {
+			row[j] = new(big.Int)
+		}
+		row[i].SetInt64(1)
+		row[d+1].Set(Approximate(power, -prec))
+		basis[i] = row
+		power = Multiply(power, c)
+	}
+
+	lll(basis)
+
+	q := make(ratPoly, d+1)
+	for i := range q {
+		q[i] = new(big.Rat).SetInt(basis[0][i])
+	}
+	q = q.trim()
+	if q.degree() < 1 {
+		return nil
+	}
+	return q
+}
+
+// lll reduces the linearly independent rows of basis in place, with the
+// integral LLL algorithm of Cohen's "A Course in Computational Algebraic
+// Number Theory", algorithm 2.6.7, for δ = 3/4.
+func lll(basis [][]*big.Int) {
+	n := len(basis)
+	dot := func(a, b []*big.Int) *big.Int {
+		r, t := new(big.Int), new(big.Int)
+		for i := range a {
+			r.Add(r, t.Mul(a[i], b[i]))
+		}
+		return r
+	}
+
+	// b, d, and λ are indexed from 1 as in the algorithm, with d[0] = 1
+	b := append([][]*big.Int{nil}, basis...)
+	d := make([]*big.Int, n+1)
+	d[0] = big.NewInt(1)
+	lambda := make([][]*big.Int, n+1)
+	for i := range lambda {
+		lambda[i] = make([]*big.Int, n+1)
+		for j := range lambda[i] {
+			lambda[i][j] = new(big.Int)
+		}
+	}
+
+	red := func(k, l int) {
+		if new(big.Int).Lsh(bigAbs(lambda[k][l]), 1).Cmp(d[l]) <= 0 {
+			return
+		}
+
+		// q = round(λ[k][l] / d[l])
+		q := new(big.Int).Lsh(lambda[k][l], 1)
+		q.Add(q, d[l])
+		q.Div(q, new(big.Int).Lsh(d[l], 1))
+
+		t := new(big.Int)
+		for j := range b[k] {
+			b[k][j].Sub(b[k][j], t.Mul(q, b[l][j]))
+		}
+		lambda[k][l].Sub(lambda[k][l], t.Mul(q, d[l]))
+		for i := 1; i < l; i++ {
+			lambda[k][i].Sub(lambda[k][i], t.Mul(q, lambda[l][i]))
+		}
+	}
+
+	swap := func(k, kmax int) {
+		b[k], b[k-1] = b[k-1], b[k]
+		for j := 1; j <= k-2; j++ {
+			lambda[k][j], lambda[k-1][j] = lambda[k-1][j], lambda[k][j]
+		}
+
+		l := new(big.Int).Set(lambda[k][k-1])
+		bb := new(big.Int).Mul(d[k-2], d[k])
+		bb.Add(bb, new(big.Int).Mul(l, l))
+		bb.Quo(bb, d[k-1])
+		for i := k + 1; i <= kmax; i++ {
+			t := new(big.Int).Set(lambda[i][k])
+			lik := new(big.Int).Mul(d[k], lambda[i][k-1])
+			lik.Sub(lik, new(big.Int).Mul(l, t))
+			lik.Quo(lik, d[k-1])
+			lambda[i][k] = lik
+
+			lik1 := new(big.Int).Mul(bb, t)
+			lik1.Add(lik1, new(big.Int).Mul(l, lik))
+			lik1.Quo(lik1, d[k])
+			lambda[i][k-1] = lik1
+		}
+		d[k-1] = bb
+	}
+
+	d[1] = dot(b[1], b[1])
+	for k, kmax := 2, 1; k <= n; {
+		if k > kmax {
+			kmax = k
+			for j := 1; j <= k; j++ {
+				u := dot(b[k], b[j])
+				for i := 1; i < j; i++ {
+					u.Mul(u, d[i])
+					u.Sub(u, new(big.Int).Mul(lambda[k][i], lambda[j][i]))
+					u.Quo(u, d[i-1])
+				}
+				if j < k {
+					lambda[k][j] = u
+				} else {
+					d[k] = u
+				}
+			}
+		}
+
+		red(k, k-1)
+
+		// 4 d[k] d[k-2] < 3 d[k-1]² - 4 λ[k][k-1]²
+		lhs := new(big.Int).Mul(d[k], d[k-2])
+		lhs.Lsh(lhs, 2)
+		rhs := new(big.Int).Mul(d[k-1], d[k-1])
+		rhs.Mul(rhs, big.NewInt(3))
+		rhs.Sub(rhs, new(big.Int).Lsh(new(big.Int).Mul(lambda[k][k-1], lambda[k][k-1]), 2))
+		if lhs.Cmp(rhs) < 0 {
+			swap(k, kmax)
+			k = max(2, k-1)
+			continue
+		}
+
+		for l := k - 2; l >= 1; l-- {
+			red(k, l)
+		}
+		k++
+	}
+
+	copy(basis, b[1:])
+}
@@ -0,0 +1,87 @@
+package constructive
+
+import "math/big"
+
+// Binomial computes the binomial coefficient C(n, k) exactly as an integer,
+// or nil when n < 0, k < 0, or k > n.
+func Binomial(n, k int) Real {
+	if n < 0 || k < 0 || k > n {
+		return nil
+	}
+
+	return FromBigInt(new(big.Int).Binomial(int64(n), int64(k)))
+}
+
+// Factorial computes n! exactly as an integer, or nil when n < 0.
+func Factorial(n int) Real {
+	if n < 0 {
+		return nil
+	}
+
+	return FromBigInt(new(big.Int).MulRange(1, int64(n)))
+}
+
+// Fibonacci computes the n-th Fibonacci number Fₙ exactly as an integer,
+// where F₀ = 0, F₁ = 1, and Fₙ = Fₙ₋₁ + Fₙ₋₂. Negative n are extended by the
+// same recurrence, so that F₋ₙ = (-1)ⁿ⁺¹ Fₙ.
+func Fibonacci(n int) Real {
+	f, _ := fibonacciPair(max(n, -n))
+	if n < 0 && n%2 == 0 {
+		f.Neg(f)
+	}
+
+	return FromBigInt(f)
+}
+
+// Lucas computes the n-th Lucas number Lₙ = Fₙ₋₁ + Fₙ₊₁ exactly as an
+// integer, where L₀ = 2 and L₁ = 1. Negative n are extended by the same
+// recurrence, so that L₋ₙ = (-1)ⁿ Lₙ.
+func Lucas(n int) Real {
+	// Lₙ = 2Fₙ₊₁ - Fₙ
+	f, g := fibonacciPair(max(n, -n))
+	l := g.Lsh(g, 1).Sub(g, f)
+	if n < 0 && n%2 != 0 {
+		l.Neg(l)
+	}
+
+	return FromBigInt(l)
+}
+
+// FibonacciBinet computes the n-th Fibonacci number using Binet's formula,
+//
+// Fₙ = (φⁿ - ψⁿ) / √5
+//
+// where ψ = 1 - φ. Unlike Fibonacci, the result is not constructed as an
+// integer, even though it is equal to one.
+func FibonacciBinet(n int) Real {
+	if n == 0 {
+		return Zero()
+	}
+
+	phi, psi := Phi(), Subtract(One(), Phi())
+	if n < 0 {
+		phi, psi = Inverse(phi), Inverse(psi)
+	}
+
+	m := max(n, -n)
+	return Divide(Subtract(powInt(phi, m), powInt(psi, m)), Sqrt(FromInt(5)))
+}
+
+// fibonacciPair computes Fₙ and Fₙ₊₁ for n ≥ 0 using fast doubling:
+//
+// F₂ₖ = Fₖ (2Fₖ₊₁ - Fₖ), F₂ₖ₊₁ = Fₖ² + Fₖ₊₁²
+func fibonacciPair(n int) (*big.Int, *big.Int) {
+	if n == 0 {
+		return big.NewInt(0), big.NewInt(1)
+	}
+
+	a, b := fibonacciPair(n / 2)
+	c := new(big.Int).Lsh(b, 1)
+	c.Sub(c, a).Mul(c, a)
+	d := new(big.Int).Mul(a, a)
+	d.Add(d, b.Mul(b, b))
+	if n%2 == 1 {
+		return d, c.Add(c, d)
+	}
+	return c, d
+}
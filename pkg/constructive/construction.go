@@ -0,0 +1,102 @@
+package constructive
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AsConstructionDAG is like AsConstruction, but nodes referenced more than
+// once are bound once with `let xN = …;` and referred to by name, so the
+// output stays linear in the number of distinct nodes rather than growing
+// exponentially with repeated sharing, as in `Square(Square(Square(x)))`.
+func AsConstructionDAG(c Real) string {
+	refs := map[Real]int{}
+	var count func(Real)
+	count = func(c Real) {
+		refs[c]++
+		if refs[c] > 1 {
+			return
+		}
+		for _, o := range operands(c) {
+			count(o)
+		}
+	}
+	count(c)
+
+	sb := &strings.Builder{}
+	names := map[Real]string{}
+	var ref func(Real) string
+	ref = func(c Real) string {
+		if name, ok := names[c]; ok {
+			return name
+		}
+		return constructionWith(c, ref)
+	}
+
+	seen := map[Real]bool{}
+	var bind func(Real)
+	bind = func(c Real) {
+		if seen[c] {
+			return
+		}
+
+		seen[c] = true
+		for _, o := range operands(c) {
+			bind(o)
+		}
+		if refs[c] > 1 {
+			name := fmt.Sprintf("x%d", len(names))
+			fmt.Fprintf(sb, "let %s = %s; ", name, constructionWith(c, ref))
+			names[c] = name
+		}
+	}
+	bind(c)
+
+	sb.WriteString(ref(c))
+	return sb.String()
+}
+
+// constructionWith renders c the same way as its asConstruction method, but
+// renders its operands using ref.
+func constructionWith(c Real, ref func(Real) string) string {
+	switch v := c.(type) {
+	case *named:
+		return fmt.Sprintf("Named(%q, %s)", v.Name, ref(v.Real))
+	case *constructiveAddition:
+		return fmt.Sprintf("Add(%s, %s)", ref(v.a), ref(v.b))
+	case *constructiveMultiplication:
+		return fmt.Sprintf("Multiply(%s, %s)", ref(v.a), ref(v.b))
+	case *constructiveMultiplicativeInverse:
+		return fmt.Sprintf("Inverse(%s)", ref(v.r))
+	case *constructiveShift:
+		if v.n < 0 {
+			return fmt.Sprintf("ShiftRight(%s, %d)", ref(v.r), -v.n)
+		}
+		return fmt.Sprintf("ShiftLeft(%s, %d)", ref(v.r), v.n)
+	case *constructiveNegation:
+		return fmt.Sprintf("Negate(%s)", ref(v.r))
+	case *constructiveCondsign:
+		return fmt.Sprintf("CondSign(%s, %s, %s)", ref(v.r), ref(v.a), ref(v.b))
+	case *prescaledExponential:
+		return fmt.Sprintf("Pow(E, %s)", ref(v.r))
+	case *prescaledNaturalLog:
+		return fmt.Sprintf("Ln(%s)", ref(v.r))
+	case *prescaledSqrt:
+		return fmt.Sprintf("Sqrt(%s)", ref(v.r))
+	case *nthRoot:
+		return fmt.Sprintf("NthRoot(%s, %d)", ref(v.r), v.n)
+	case *prescaledCosine:
+		return fmt.Sprintf("Cosine(%s)", ref(v.r))
+	case *integralArctan:
+		return fmt.Sprintf("IntegralArctan(%s)", ref(v.a))
+	case *lambertW:
+		return fmt.Sprintf("LambertW(%s)", ref(v.r))
+	case *powerSeries:
+		return fmt.Sprintf("Series(%s)", ref(v.x))
+	case *bisectionRoot:
+		ops := operands(v)
+		return fmt.Sprintf("BisectionRoot(%s, %s)", ref(ops[0]), ref(ops[1]))
+	default:
+		return c.asConstruction()
+	}
+}
@@ -1,10 +1,14 @@
 package constructive
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
+	"math/bits"
 	"strings"
+	"sync"
 )
 
 const IntSize = 32 << (^uint(0) >> 63) // 32 or 64
@@ -34,15 +38,65 @@ func Text(c Real, dec, radix int) (text string) {
 		}
 	}()
 
-	var sc Real
-	if radix == 16 {
-		sc = ShiftLeft(c, 4*dec)
-	} else {
-		sf := bigExp(big.NewInt(int64(radix)), big.NewInt(int64(dec)), nil)
-		sc = Multiply(c, newInteger(sf))
+	return formatText(Approximate(scaleForText(c, dec, radix), 0), dec, radix)
+}
+
+// TextBits converts a Real number to a binary string with exactly bits
+// binary digits after the point, like Text(c, bits, 2). As with Text, the
+// last digit may be off by one.
+func TextBits(c Real, bits int) string {
+	return Text(c, bits, 2)
+}
+
+// TextBitsPrefixed is like TextBits, but marks the digits with a leading 0b
+// after the sign, as in -0b1.1000.
+func TextBitsPrefixed(c Real, bits int) string {
+	text := TextBits(c, bits)
+	if strings.HasPrefix(text, "<") {
+		return text
+	}
+
+	if neg, ok := strings.CutPrefix(text, "-"); ok {
+		return "-0b" + neg
+	}
+	return "0b" + text
+}
+
+// TextContext is like Text, but returns ctx.Err() if ctx is done before the
+// text is ready, or PrecisionOverflow if the number of bits needed for dec
+// digits exceeds the precision limit of ctx. See ApproximateContext for how
+// the approximation is abandoned.
+func TextContext(ctx context.Context, c Real, dec, radix int) (string, error) {
+	bits := int(math.Ceil(float64(dec) * math.Log2(float64(radix))))
+	if err := CheckPrecisionOverflow(ctx, bits); err != nil {
+		return "", err
+	}
+
+	si, err := ApproximateContext(ctx, scaleForText(c, dec, radix), 0)
+	if err != nil {
+		return "", err
+	}
+
+	return formatText(si, dec, radix), nil
+}
+
+// scaleForText scales c by radix^dec, so that its approximation at precision
+// 0 has the digits needed for Text.
+func scaleForText(c Real, dec, radix int) Real {
+	switch radix {
+	case 2:
+		return ShiftLeft(c, dec)
+	case 16:
+		return ShiftLeft(c, 4*dec)
 	}
 
-	si := Approximate(sc, 0)
+	sf := bigExp(big.NewInt(int64(radix)), big.NewInt(int64(dec)), nil)
+	return Multiply(c, newInteger(sf))
+}
+
+// formatText formats si, an approximation scaled by radix^dec, as a number
+// with dec digits after the point.
+func formatText(si *big.Int, dec, radix int) string {
 	ss := bigAbs(si).Text(radix)
 
 	out := ss
@@ -69,13 +123,132 @@ func Approximate(c Real, p int) *big.Int {
 		return nil
 	}
 
-	t := c.tracker()
-	if s, ok := t.Get(p); ok {
-		return s
+	// integer leaves are exact and cheap to scale, so they skip coordination
+	if ci, ok := Unwrap(c).(*constructiveInteger); ok {
+		return ci.ComputeLocked(p, ci.approximate)
+	}
+
+	return c.tracker().Compute(p, c.approximate)
+}
+
+// CachedPrecision returns the finest precision at which an approximation of c
+// is currently cached, and false if none is. Approximations at that precision
+// or coarser are answered from the cache without recomputation.
+func CachedPrecision(c Real) (int, bool) {
+	_, p, ok := c.tracker().Approximation()
+	if !ok {
+		return 0, false
+	}
+	return p, true
+}
+
+// Ulp returns 2^p, the bound on the error of Approximate(c, p) once scaled
+// back by 2^p; that is, |Approximate(c, p)·2^p - c| < Ulp(c, p). The bound
+// does not depend on c, which is accepted for clarity at the call site.
+func Ulp(c Real, p int) Real {
+	return Pow2Int(p)
+}
+
+// ApproximateContext is like Approximate, but returns ctx.Err() if ctx is
+// done before the approximation is complete, or PrecisionOverflow if -p
+// exceeds the precision limit of ctx. A panic during the approximation, such
+// as a division by zero, is returned as an error.
+//
+// Approximations cannot be interrupted partway, so an abandoned approximation
+// continues in the background until it completes, and its result is cached on
+// the nodes as usual. Only the caller is released early.
+func ApproximateContext(ctx context.Context, c Real, p int) (*big.Int, error) {
+	if err := CheckPrecisionOverflow(ctx, -p); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		appr *big.Int
+		err  error
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err, ok := r.(error)
+				if !ok {
+					err = fmt.Errorf("%v", r)
+				}
+				done <- result{err: err}
+			}
+		}()
+
+		done <- result{appr: Approximate(c, p)}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case r := <-done:
+		return r.appr, r.err
 	}
+}
 
-	s := c.approximate(p)
-	return t.Set(p, s)
+// ApproximateAll approximates each of cs at the same precision p, returning
+// the approximations in the same order. Like Approximate, the results may be
+// shared with the nodes' caches, and must not be modified.
+func ApproximateAll(cs []Real, p int) []*big.Int {
+	out := make([]*big.Int, len(cs))
+	for i, c := range cs {
+		out[i] = Approximate(c, p)
+	}
+	return out
+}
+
+// ApproximateAllParallel is like ApproximateAll, but approximates cs using up
+// to workers goroutines. When workers is less than two, it falls back to
+// ApproximateAll.
+//
+// Like ApproximateAll, it panics in the calling goroutine when approximating
+// any of cs panics, with the panic of the first such element in cs, once all
+// workers are done.
+func ApproximateAllParallel(cs []Real, p, workers int) []*big.Int {
+	if workers < 2 || len(cs) < 2 {
+		return ApproximateAll(cs, p)
+	}
+
+	out := make([]*big.Int, len(cs))
+	panics := make([]any, len(cs))
+	approximate := func(i int) {
+		defer func() {
+			panics[i] = recover()
+		}()
+		out[i] = Approximate(cs[i], p)
+	}
+
+	next := make(chan int)
+	wg := sync.WaitGroup{}
+	for w := 0; w < min(workers, len(cs)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				approximate(i)
+			}
+		}()
+	}
+
+	for i := range cs {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	for _, r := range panics {
+		if r != nil {
+			panic(r)
+		}
+	}
+	return out
 }
 
 // AsConstruction returns a string representing the construction of the
@@ -141,7 +314,21 @@ func AsConstructionIndent(c Real, indent string) string {
 //
 // This function never terminates if `a == b`; use PreciseCmp instead.
 func Cmp(a, b Real) int {
-	for p := -20; ; p *= 2 {
+	return CmpSchedule(a, b, -20, 2)
+}
+
+// CmpSchedule compares two Real numbers a and b like Cmp, but starting at
+// precision start, which must be negative, and multiplying the precision by
+// factor, which must be at least 2, after each inconclusive comparison. It
+// returns 0 if the schedule is invalid, or once the precision is exhausted.
+//
+// Like Cmp, this function never terminates if `a == b` in practice.
+func CmpSchedule(a, b Real, start, factor int) int {
+	if start >= 0 || factor < 2 {
+		return 0
+	}
+
+	for p := start; ; p *= factor {
 		if !IsPrecisionValid(p) {
 			return 0
 		}
@@ -173,6 +360,86 @@ func PreciseCmp(a, b Real, p int) int {
 	return 0
 }
 
+// DecimalPrecision returns the coarsest binary precision p at which 2^p is at
+// most 10^-decimals, that is, the precision needed to resolve the given
+// number of decimal places.
+func DecimalPrecision(decimals int) int {
+	return -int(math.Ceil(float64(decimals) * math.Log2(10)))
+}
+
+// EqualToDecimals reports whether a and b agree to the given number of
+// decimal places, by comparing them with PreciseCmp at DecimalPrecision of
+// the decimal places. Equal numbers always agree, and numbers that agree
+// differ by less than 1.5·10^-decimals; in between, the result depends on
+// the approximations. Unlike comparing the output of Text, the result does
+// not depend on where the numbers fall relative to a rounding boundary.
+func EqualToDecimals(a, b Real, decimals int) bool {
+	return PreciseCmp(a, b, DecimalPrecision(decimals)) == 0
+}
+
+// WithinAbs reports whether a and b are within an absolute tolerance tol of
+// each other, that is, |a-b| <= tol. Differences indistinguishable from tol
+// at a precision of 2^-100 are considered to be within tolerance.
+func WithinAbs(a, b, tol Real) bool {
+	return PreciseSign(Subtract(tol, Abs(Subtract(a, b))), zeroPrecision) >= 0
+}
+
+// WithinRel reports whether a and b are within a relative tolerance tol of
+// each other, that is, |a-b| <= tol * max(|a|, |b|). Like WithinAbs, it
+// terminates by deciding at a precision of 2^-100.
+func WithinRel(a, b, tol Real) bool {
+	bound := Multiply(tol, Max(Abs(a), Abs(b)))
+	return PreciseSign(Subtract(bound, Abs(Subtract(a, b))), zeroPrecision) >= 0
+}
+
+// NearestInteger computes the integer nearest to c, and reports whether c is
+// within 2^p of it. When c lies within 2^(p-2) or 1/16, whichever is less,
+// of halfway between two integers, either may be returned. Unlike a floor, this always terminates,
+// even when c is exactly an integer.
+func NearestInteger(c Real, p int) (*big.Int, bool) {
+	appr := Approximate(c, -2)
+	if appr == nil {
+		return nil, false
+	}
+
+	// n is within 3/4 of c, so that it may need a step toward c, which is
+	// decided from the difference at a precision of at least 2^-4
+	n := scale(appr, -2)
+	q := min(p, -2) - 2
+	d := Approximate(Subtract(c, FromBigInt(n)), q)
+	if d == nil {
+		return n, false
+	}
+
+	// |d| > 2^(-1-q) guarantees |c - n| >= 1/2, and then |c - n ∓ 1| <= 1/2
+	half := bigLsh(big.NewInt(1), uint(-1-q))
+	if d.CmpAbs(half) > 0 {
+		step := big.NewInt(int64(d.Sign()))
+		n.Add(n, step)
+		d.Sub(d, step.Lsh(step, uint(-q)))
+	}
+
+	// |d| < 2^(p-q) guarantees |c - n| < (|d| + 1) * 2^q <= 2^p
+	return n, d.CmpAbs(bigLsh(big.NewInt(1), uint(p-q))) < 0
+}
+
+// Floor computes the greatest integer not greater than c, or nil if c cannot
+// be approximated.
+//
+// Since an exact integer cannot be decided, c is treated as an integer when
+// it is indistinguishable from one at a precision of 2^-100.
+func Floor(c Real) Real {
+	n, _ := NearestInteger(c, zeroPrecision)
+	if n == nil {
+		return nil
+	}
+
+	if PreciseSign(Subtract(c, FromBigInt(n)), zeroPrecision) < 0 {
+		n.Sub(n, big.NewInt(1))
+	}
+	return FromBigInt(n)
+}
+
 // Real represents a constructive real number.
 type Real interface {
 	approximate(int) *big.Int
@@ -182,20 +449,18 @@ type Real interface {
 
 // knownMSD computes the position of the most significant digit (MSD). When
 // the MSD is n, then 2^(n-1) < |c| < 2^(n+1).
+//
+// The MSD is memoized on the node's precision tracker, since it is queried
+// repeatedly by multiplication and inversion of shared subtrees.
 func knownMSD(c Real) int {
-	t := c.tracker()
-	if t.MaxApproximation.Sign() >= 0 {
-		return t.MinPrecision + t.MaxApproximation.BitLen() - 1
-	}
-
-	return t.MinPrecision + bigNeg(t.MaxApproximation).BitLen() - 1
+	return c.tracker().MSD()
 }
 
 func msd(c Real, n int) int {
 	t := c.tracker()
-	if !t.IsValid || (t.MaxApproximation.Cmp(big.NewInt(1)) <= 0 && t.MaxApproximation.Cmp(big.NewInt(-1)) >= 0) {
+	if appr, _, ok := t.Approximation(); !ok || appr.CmpAbs(bigOne) <= 0 {
 		_ = Approximate(c, n-1) // for side effects :(
-		if bigAbs(t.MaxApproximation).Cmp(big.NewInt(1)) <= 0 {
+		if appr, _, _ := t.Approximation(); appr.CmpAbs(bigOne) <= 0 {
 			return math.MinInt
 		}
 	}
@@ -203,11 +468,23 @@ func msd(c Real, n int) int {
 	return knownMSD(c)
 }
 
+// MSD computes the position n of the most significant binary digit of c, such
+// that 2^(n-1) < |c| < 2^(n+1); the position is only known to within one, so
+// for example, the MSD of 8 may be reported as either 3 or 4. It returns false
+// when c is indistinguishable from zero at a precision of 2^-100.
+func MSD(c Real) (int, bool) {
+	n := msd(c, zeroPrecision)
+	if n == math.MinInt {
+		return 0, false
+	}
+
+	return n, true
+}
+
 // PreciseSign computes the sign of a Real number c given precision p.
 func PreciseSign(c Real, p int) int {
-	if t := c.tracker(); t.IsValid {
-		v := t.MaxApproximation.Sign()
-		if v != 0 {
+	if appr, _, ok := c.tracker().Approximation(); ok {
+		if v := appr.Sign(); v != 0 {
 			return v
 		}
 	}
@@ -220,6 +497,28 @@ func PreciseSign(c Real, p int) int {
 	return ic.Sign()
 }
 
+// quickSignPrecision is the precision at which QuickSign and conditional
+// sign selection take their cheap sign hint.
+const quickSignPrecision = -20
+
+// QuickSign computes a cheap hint of the sign of c from a single
+// approximation at a precision of 2^-20. It returns 1 if c > 0, or -1 if
+// c < 0, or 0 if c is too close to zero to tell at that precision, in which
+// case the caller may fall back to PreciseSign or Sign.
+func QuickSign(c Real) int {
+	return Approximate(c, quickSignPrecision).Sign()
+}
+
+// deepSign computes the sign of c like QuickSign, but refines it with
+// PreciseSign down to the precision that the inverse searches to, when c is
+// too close to zero to tell at 2^-20.
+func deepSign(c Real) int {
+	if sign := QuickSign(c); sign != 0 {
+		return sign
+	}
+	return PreciseSign(c, inverseMSDPrecision)
+}
+
 // Sign computes the sign of a Real number c. It returns 1 if c > 0,
 // or -1 if c < 0.
 //
@@ -234,12 +533,24 @@ func Sign(c Real) int {
 
 // scale is a rounded multiplication by 2^n.
 func scale(i *big.Int, n int) *big.Int {
+	return scaleInto(new(big.Int), i, n)
+}
+
+// scaleInto is a rounded multiplication by 2^n like scale, but stores the
+// result in dst, which may alias i, and returns dst. It avoids allocating in
+// the inner loops of series approximations.
+func scaleInto(dst, i *big.Int, n int) *big.Int {
 	if n >= 0 {
-		return bigLsh(i, uint(n))
+		return dst.Lsh(i, uint(n))
 	}
 
-	adj := bigAdd(signedShift(i, n+1), big.NewInt(1))
-	return bigRsh(adj, 1)
+	if n+1 < 0 {
+		dst.Rsh(i, uint(-(n + 1)))
+	} else {
+		dst.Set(i)
+	}
+	dst.Add(dst, bigOne)
+	return dst.Rsh(dst, 1)
 }
 
 // signedShift is a signed shift function.
@@ -324,6 +635,42 @@ func FromFloat32Slice(floats []float32) []Real {
 	return reals
 }
 
+// float32Guard is the number of bits beyond the 24-bit significand of a
+// float32 that Float32 approximates, so that the approximation error of one
+// unit rarely affects the rounding.
+const float32Guard = 8
+
+// float32MinPrecision is the precision below which a Real is rounded to zero
+// by Float32, since the smallest subnormal float32 is 2^-149.
+const float32MinPrecision = -151
+
+// Float32 computes the float32 nearest to c, and reports false when c is
+// too large in magnitude for a float32, in which case ±Inf is returned.
+// Structurally rational numbers, as determined by Identify, are rounded
+// exactly; others are approximated with 24+8 significant bits and rounded
+// from there, so that the result may differ by one ulp from the nearest
+// float32 when c lies extremely close to halfway between two.
+func Float32(c Real) (float32, bool) {
+	if r, ok, _ := Identify(c); ok {
+		f, _ := r.Float32()
+		return f, !math.IsInf(float64(f), 0)
+	}
+
+	n := msd(c, float32MinPrecision)
+	if n == math.MinInt {
+		return 0, true
+	}
+
+	p := n - 24 - float32Guard
+	appr := Approximate(c, p)
+	if appr == nil {
+		return 0, false
+	}
+
+	f, _ := new(big.Float).SetMantExp(new(big.Float).SetInt(appr), p).Float32()
+	return f, !math.IsInf(float64(f), 0)
+}
+
 // FromFloat64 creates a Real number from a float64.
 func FromFloat64(f float64) Real {
 	if math.IsNaN(f) || math.IsInf(f, 0) {
@@ -361,6 +708,48 @@ func FromRat(a, b int) Real {
 	return Divide(FromInt(a), FromInt(b))
 }
 
+// constructiveRational represents an exact rational number.
+type constructiveRational struct {
+	precisionTracker
+	r *big.Rat
+}
+
+// FromRatExact creates a Real number from a big.Rat. Unlike FromRat, which
+// builds a division, the result remembers that it is rational, so it can be
+// identified exactly without inspecting its construction.
+func FromRatExact(r *big.Rat) Real {
+	if r == nil {
+		return nil
+	}
+
+	return &constructiveRational{
+		r: new(big.Rat).Set(r),
+	}
+}
+
+// Rat returns a copy of the exact rational value.
+func (c *constructiveRational) Rat() *big.Rat {
+	return new(big.Rat).Set(c.r)
+}
+
+func (c *constructiveRational) approximate(p int) *big.Int {
+	// round(num * 2^-p / denom) = floor((2a + b) / 2b), where a/b is the
+	// fraction with the power of two moved into the numerator or denominator
+	a := c.r.Num()
+	b := c.r.Denom()
+	if p < 0 {
+		a = bigLsh(a, uint(-p))
+	} else {
+		b = bigLsh(b, uint(p))
+	}
+
+	return bigDiv(bigAdd(bigLsh(a, 1), b), bigLsh(b, 1))
+}
+
+func (c *constructiveRational) asConstruction() string {
+	return fmt.Sprintf("Rat(%s)", c.r.RatString())
+}
+
 func newInteger(i *big.Int) Real {
 	return &constructiveInteger{
 		i: i,
@@ -368,9 +757,36 @@ func newInteger(i *big.Int) Real {
 }
 
 func (c *constructiveInteger) approximate(p int) *big.Int {
+	if appr, ok := scaleInt64(c.i, -p); ok {
+		return appr
+	}
 	return scale(c.i, -p)
 }
 
+// scaleInt64 is a rounded multiplication by 2^n like scale, but computed with
+// int64 arithmetic when i and the result fit in 63 bits, which avoids most of
+// the allocations of scale. It returns false when they do not fit, including
+// when rounding could overflow.
+func scaleInt64(i *big.Int, n int) (*big.Int, bool) {
+	if !i.IsInt64() {
+		return nil, false
+	}
+
+	v := i.Int64()
+	size := bits.Len64(uint64(max(v, -v)))
+	switch {
+	case size > 62 || n > 62-size:
+		return nil, false
+	case n >= 0:
+		return big.NewInt(v << n), true
+	case n < -63:
+		// v >> 63 is 0 or -1, both of which round to 0
+		return big.NewInt(0), true
+	default:
+		return big.NewInt((v>>(-n-1) + 1) >> 1), true
+	}
+}
+
 func (c *constructiveInteger) asConstruction() string {
 	return fmt.Sprintf("Int(%s)", c.i.Text(10))
 }
@@ -385,6 +801,45 @@ func Subtract(a, b Real) Real {
 	return newAddition(a, Negate(b))
 }
 
+// Sum computes the sum of all cs, or zero if there are none. The additions
+// are arranged in a balanced tree rather than a chain, which keeps the depth
+// of the construction logarithmic in the number of terms, and so bounds the
+// extra precision each term must be approximated at.
+func Sum(cs ...Real) Real {
+	switch len(cs) {
+	case 0:
+		return Zero()
+	case 1:
+		return cs[0]
+	}
+
+	mid := len(cs) / 2
+	return Add(Sum(cs[:mid]...), Sum(cs[mid:]...))
+}
+
+// SumSlice computes the sum of all cs, like Sum. Each addition approximates
+// its operands two bits finer than its own precision, so a chain of n
+// additions would need the first terms 2n bits finer, whereas the balanced
+// tree only needs about 2·log₂(n) bits.
+func SumSlice(cs []Real) Real {
+	return Sum(cs...)
+}
+
+// CumSum computes the running sums of cs, where the i-th result is the sum of
+// cs[0] through cs[i]. Each sum is built from the previous one, so they share
+// their approximations.
+func CumSum(cs []Real) []Real {
+	out := make([]Real, len(cs))
+	for i, c := range cs {
+		if i == 0 {
+			out[i] = c
+			continue
+		}
+		out[i] = Add(out[i-1], c)
+	}
+	return out
+}
+
 type constructiveAddition struct {
 	precisionTracker
 	a Real
@@ -423,6 +878,27 @@ func Multiply(a, b Real) Real {
 	return newMultiplication(a, b)
 }
 
+// Product computes the product of all cs, or one if there are none. Like Sum,
+// the multiplications are arranged in a balanced tree.
+func Product(cs ...Real) Real {
+	switch len(cs) {
+	case 0:
+		return One()
+	case 1:
+		return cs[0]
+	}
+
+	mid := len(cs) / 2
+	return Multiply(Product(cs[:mid]...), Product(cs[mid:]...))
+}
+
+// ProductSlice computes the product of all cs, like Product. Products of
+// integers stay exact, however large; the balanced tree bounds the extra
+// precision needed by the other factors, as it does for SumSlice.
+func ProductSlice(cs []Real) Real {
+	return Product(cs...)
+}
+
 func newMultiplication(a, b Real) Real {
 	return &constructiveMultiplication{
 		a: a,
@@ -459,17 +935,43 @@ func (c *constructiveMultiplication) asConstruction() string {
 	return fmt.Sprintf("Multiply(%s, %s)", c.a.asConstruction(), c.b.asConstruction())
 }
 
+// ErrDivisionByZero is returned when dividing by a value that is
+// indistinguishable from zero.
+var ErrDivisionByZero = errors.New("division by zero")
+
 // Inverse computes the multiplicative inverse, which is 1/c.
 func Inverse(c Real) Real {
 	return newMultiplicativeInverse(c)
 }
 
+// InverseErr computes the multiplicative inverse like Inverse, but checks
+// up front whether c is indistinguishable from zero at a precision of 2^-100,
+// returning ErrDivisionByZero instead of a node that fails on approximation.
+func InverseErr(c Real) (Real, error) {
+	if PreciseSign(c, zeroPrecision) == 0 {
+		return nil, ErrDivisionByZero
+	}
+
+	return Inverse(c), nil
+}
+
 // Divide computes the division `a * (1/b)`, where `1/b` is the multiplicative
 // inverse of b.
 func Divide(a, b Real) Real {
 	return Multiply(a, Inverse(b))
 }
 
+// DivideErr computes the division `a / b` like Divide, but returns
+// ErrDivisionByZero when b is indistinguishable from zero, as in InverseErr.
+func DivideErr(a, b Real) (Real, error) {
+	ib, err := InverseErr(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return Multiply(a, ib), nil
+}
+
 type constructiveMultiplicativeInverse struct {
 	precisionTracker
 	r Real
@@ -481,8 +983,18 @@ func newMultiplicativeInverse(r Real) Real {
 	}
 }
 
+// inverseMSDPrecision bounds the search for the most significant digit of
+// the operand of an inverse, which may lie well below the precision that the
+// inverse is approximated at, as for e^-100. An operand indistinguishable
+// from zero at this precision is treated as zero.
+const inverseMSDPrecision = -4096
+
 func (c *constructiveMultiplicativeInverse) approximate(p int) *big.Int {
 	mr := msd(c.r, p)
+	for q := min(p, -1); mr == math.MinInt && q > inverseMSDPrecision; {
+		q = max(2*q, inverseMSDPrecision)
+		mr = msd(c.r, q)
+	}
 	ir := 1 - mr
 
 	digits := ir - p + 3
@@ -498,8 +1010,8 @@ func (c *constructiveMultiplicativeInverse) approximate(p int) *big.Int {
 	absolute := bigAbs(divisor)
 	adj := bigAdd(dividend, bigRsh(absolute, 1))
 
-	res := bigDiv(adj, divisor)
-	if res.Sign() < 0 {
+	res := bigDiv(adj, absolute)
+	if divisor.Sign() < 0 {
 		return bigNeg(res)
 	}
 	return res
@@ -555,6 +1067,15 @@ func Negate(c Real) Real {
 	return newNegation(c)
 }
 
+// NegationOperand returns r and true when c, ignoring any names, was
+// constructed as Negate(r); otherwise, it returns nil and false.
+func NegationOperand(c Real) (Real, bool) {
+	if n, ok := Unwrap(c).(*constructiveNegation); ok {
+		return n.r, true
+	}
+	return nil, false
+}
+
 type constructiveNegation struct {
 	precisionTracker
 	r Real
@@ -579,6 +1100,54 @@ func Abs(c Real) Real {
 	return newCondsign(c, Negate(c), c)
 }
 
+// zeroPrecision is the precision at which Signum, MSD, InverseErr, and the
+// tolerance comparisons decide whether their argument is zero.
+const zeroPrecision = -100
+
+// Signum computes the sign of c as a Real number: -1 if c < 0, 0 if c == 0,
+// or 1 if c > 0. Unlike Sign, it returns a Real number, so it composes with
+// other operations.
+//
+// Since an exact zero cannot be decided, c is treated as zero when it is
+// indistinguishable from zero at a precision of 2^-100.
+func Signum(c Real) Real {
+	if PreciseSign(c, zeroPrecision) == 0 {
+		return Zero()
+	}
+
+	return newCondsign(c, FromInt(-1), One())
+}
+
+// Heaviside computes the Heaviside step function of c: 0 if c < 0, 1/2 if
+// c == 0, or 1 if c > 0. Like Signum, c is treated as zero when it is
+// indistinguishable from zero at a precision of 2^-100.
+func Heaviside(c Real) Real {
+	return ShiftRight(Add(One(), Signum(c)), 1)
+}
+
+// ReLU computes the rectified linear unit of c, which is the maximum of c
+// and zero.
+func ReLU(c Real) Real {
+	return Max(c, Zero())
+}
+
+// Logistic computes the standard logistic function of c, 1/(1 + e^-c), which
+// is also (1 + tanh(c/2))/2. Since Exp reduces a negative argument to the
+// inverse of a positive one, e^-c never needs to be approximated through a
+// long chain of squarings of a tiny number, so the result converges quickly
+// even for arguments of large magnitude.
+func Logistic(c Real) Real {
+	return Inverse(Add(One(), Exp(Negate(c))))
+}
+
+// Softplus computes ln(1 + e^c), a smooth approximation of ReLU. It is
+// computed as max(c, 0) + ln(1 + e^-|c|), so that e^c is never formed for
+// large positive c, and the logarithm keeps its relative accuracy when its
+// argument is close to 1 for large negative c.
+func Softplus(c Real) Real {
+	return Add(ReLU(c), Ln(Add(One(), Exp(Negate(Abs(c))))))
+}
+
 // Max computes the maximum of a and b.
 func Max(a, b Real) Real {
 	return newCondsign(Subtract(a, b), b, a)
@@ -589,6 +1158,19 @@ func Min(a, b Real) Real {
 	return newCondsign(Subtract(a, b), a, b)
 }
 
+// Lerp linearly interpolates between a and b, computing `a + t(b - a)`. When
+// t is the constant Zero or One, a or b is returned as-is.
+func Lerp(a, b, t Real) Real {
+	switch Unwrap(t) {
+	case Unwrap(Zero()):
+		return a
+	case Unwrap(One()):
+		return b
+	}
+
+	return Add(a, Multiply(t, Subtract(b, a)))
+}
+
 type constructiveCondsign struct {
 	precisionTracker
 	a Real
@@ -605,7 +1187,7 @@ func newCondsign(r, a, b Real) Real {
 }
 
 func (c *constructiveCondsign) approximate(p int) *big.Int {
-	switch sign := Approximate(c.r, -20).Sign(); {
+	switch sign := QuickSign(c.r); {
 	case sign < 0:
 		return Approximate(c.a, p)
 	case sign > 0:
@@ -632,6 +1214,10 @@ func (c *constructiveCondsign) asConstruction() string {
 }
 
 // Exp computes the e^c.
+//
+// The argument is reduced by halving it until it is small enough for the
+// series, e^c = (e^(c/2))^2, with the number of halvings computed up front
+// so that the construction is built iteratively.
 func Exp(c Real) Real {
 	rough := Approximate(c, -3)
 	// e^-c = 1/e^c
@@ -639,11 +1225,40 @@ func Exp(c Real) Real {
 		return Inverse(Exp(Negate(c)))
 	}
 
-	if rough.Cmp(big.NewInt(2)) > 0 {
-		return Square(Exp(ShiftRight(c, 1)))
+	// rough approximation of c/2^k at precision -3 is that of c at k-3
+	k := 0
+	for Approximate(c, k-3).Cmp(big.NewInt(2)) > 0 {
+		k++
+	}
+
+	r := newPrescaledExponential(ShiftRight(c, k))
+	for i := 0; i < k; i++ {
+		r = Square(r)
 	}
 
-	return newPrescaledExponential(c)
+	return r
+}
+
+// ErrExpRange is returned when the argument of an exponential is too large
+// in magnitude for the result to be approximated.
+var ErrExpRange = errors.New("exponential out of range")
+
+// maxExpArgument bounds the magnitude of the argument accepted by ExpErr.
+// At this bound, e^c already has about 24 million bits in its integer part,
+// or as many leading zero bits in its fractional part.
+const maxExpArgument = 1 << 24
+
+// ExpErr computes e^c like Exp, but returns ErrExpRange when |c| is so large
+// that the result cannot be meaningfully approximated; such a result either
+// has too many bits to hold in memory, or is indistinguishable from zero at
+// any practical precision. The construction built for an accepted argument
+// nests at most about 24 squarings.
+func ExpErr(c Real) (Real, error) {
+	if Approximate(c, 0).CmpAbs(big.NewInt(maxExpArgument)) > 0 {
+		return nil, fmt.Errorf("%w: |%s| exceeds %d", ErrExpRange, Text(c, 0, 10), maxExpArgument)
+	}
+
+	return Exp(c), nil
 }
 
 type prescaledExponential struct {
@@ -668,33 +1283,30 @@ func (c *prescaledExponential) approximate(p int) *big.Int {
 		return big.NewInt(0)
 	}
 
-	iters := -p/2 + 2
-	calcPrec := p - boundLog2(2*iters) - 4
+	calcPrec := seriesPrecision(p, -p/2+2)
 	opPrec := p - 3
 	opAppr := Approximate(c.r, opPrec)
 
-	// Start with term = sum = 1
-	term := bigLsh(big.NewInt(1), uint(-calcPrec))
-	sum := bigLsh(big.NewInt(1), uint(-calcPrec))
+	// x^n / n! = x^(n-1) / (n-1)! * x / n, starting with 1
 	n := int64(0)
-
-	// Iteratively compute terms until the truncation error is acceptable,
-	// which happens when the term is smaller than the maximum truncation error
-	maxTruncError := bigLsh(big.NewInt(1), uint(p-4-calcPrec))
-	for bigAbs(term).Cmp(maxTruncError) >= 0 {
+	divisor := new(big.Int)
+	return sumPrescaledSeries(p, calcPrec, bigLsh(big.NewInt(1), uint(-calcPrec)), func(term *big.Int) {
 		n++
-		term = scale(bigMul(term, opAppr), opPrec)
-		term = bigDiv(term, big.NewInt(n))
-		sum = bigAdd(sum, term)
-	}
-
-	return scale(sum, calcPrec-p)
+		scaleInto(term, term.Mul(term, opAppr), opPrec)
+		term.Div(term, divisor.SetInt64(n))
+	})
 }
 
 func (c *prescaledExponential) asConstruction() string {
 	return fmt.Sprintf("Pow(E, %s)", c.r.asConstruction())
 }
 
+// Ln computes the natural logarithm of c, for c > 0, or nil if c < 0.
+//
+// Arguments below 1/2 are inverted, using ln(c) = -ln(1/c), and arguments
+// above 3/2 are reduced by square roots, using ln(c) = 2^k ln(c^(1/2^k)),
+// with the number of square roots k estimated up front so that the
+// construction is built iteratively.
 func Ln(c Real) Real {
 	rough := Approximate(c, -4)
 	if rough.Sign() < 0 {
@@ -703,10 +1315,35 @@ func Ln(c Real) Real {
 	if rough.Cmp(big.NewInt(8)) < 0 {
 		return Negate(Ln(Inverse(c)))
 	}
-	if rough.Cmp(big.NewInt(24)) > 0 {
-		return ShiftLeft(Ln(Sqrt(Sqrt(c))), 2)
+
+	k := lnHalvings(rough)
+	r := c
+	for i := 0; i < k; i++ {
+		r = Sqrt(r)
+	}
+	// in case the estimate fell short
+	for Approximate(r, -4).Cmp(big.NewInt(24)) > 0 {
+		r = Sqrt(r)
+		k++
 	}
-	return SimpleLn(c)
+
+	return ShiftLeft(SimpleLn(r), k)
+}
+
+// lnHalvings estimates the number of square roots needed to bring c down to
+// at most 3/2, given its rough approximation at precision -4, by requiring
+// that ln(c)/2^k ≤ ln(3/2).
+func lnHalvings(rough *big.Int) int {
+	if rough.Cmp(big.NewInt(24)) <= 0 {
+		return 0
+	}
+
+	mant := new(big.Float)
+	exp := new(big.Float).SetInt(rough).MantExp(mant)
+	m, _ := mant.Float64()
+	l := math.Log(m) + float64(exp-4)*math.Ln2
+
+	return max(0, int(math.Ceil(math.Log2(l/math.Log(1.5)))))
 }
 
 // SimpleLn computes the natural logarithm of `c`, for `1 < |c| < 2`.
@@ -730,31 +1367,48 @@ func (c *prescaledNaturalLog) approximate(p int) *big.Int {
 		return big.NewInt(0)
 	}
 
-	iters := -p - 1
-	calcPrec := p - boundLog2(2*iters) - 4
+	calcPrec := seriesPrecision(p, -p-1)
 	opPrec := p - 3
 	opAppr := Approximate(c.r, opPrec)
 
+	// ±x^n / n, starting with x
 	xToTheN := scale(opAppr, opPrec-calcPrec)
-	term := xToTheN
-	sum := term
 	n := int64(1)
 	sign := int64(1)
-	maxTruncError := bigLsh(big.NewInt(1), uint(p-4-calcPrec))
-	for bigAbs(term).Cmp(maxTruncError) >= 0 {
+	divisor := new(big.Int)
+	return sumPrescaledSeries(p, calcPrec, new(big.Int).Set(xToTheN), func(term *big.Int) {
 		n++
 		sign = -sign
-		xToTheN = scale(bigMul(xToTheN, opAppr), opPrec)
-		term = bigDiv(xToTheN, big.NewInt(sign*n))
-		sum = bigAdd(sum, term)
-	}
-	return scale(sum, calcPrec-p)
+		scaleInto(xToTheN, xToTheN.Mul(xToTheN, opAppr), opPrec)
+		term.Div(xToTheN, divisor.SetInt64(sign*n))
+	})
 }
 
 func (c *prescaledNaturalLog) asConstruction() string {
 	return fmt.Sprintf("Ln(%s)", c.r.asConstruction())
 }
 
+// ArctanReciprocal computes arctan(1/n) for an integer n ≠ 0, or returns nil
+// for n = 0. This is the building block of Machin-like formulas for π, such
+// as π = 16 arctan(1/5) - 4 arctan(1/239).
+//
+// The arctangent is computed with its Taylor series, whose terms shrink by a
+// factor of n², so the larger |n| is, the faster it converges. Since the
+// series converges far too slowly for |n| = 1, arctan(1) is computed instead
+// as 2 arctan(1/3) + arctan(1/7).
+func ArctanReciprocal(n int) Real {
+	switch {
+	case n == 0:
+		return nil
+	case n < 0:
+		return Negate(ArctanReciprocal(-n))
+	case n == 1:
+		return Add(ShiftLeft(ArctanReciprocal(3), 1), ArctanReciprocal(7))
+	}
+
+	return newIntegralArctan(FromInt(n))
+}
+
 type integralArctan struct {
 	precisionTracker
 	a Real
@@ -771,28 +1425,22 @@ func (c *integralArctan) approximate(p int) *big.Int {
 		return big.NewInt(0)
 	}
 
-	iters := -p/2 + 2
-	calcPrec := p - boundLog2(2*iters) - 4
+	calcPrec := seriesPrecision(p, -p/2+2)
 
 	ia := Approximate(c.a, 0)
 	isq := bigMul(ia, ia)
 
+	// ±1 / (n a^n), for odd n, starting with 1/a
 	power := bigDiv(bigLsh(big.NewInt(1), uint(-calcPrec)), ia)
-	term := power
-	sum := power
-	sign := int64(1)
-
 	n := int64(1)
-	maxTruncError := bigLsh(big.NewInt(1), uint(p-4-calcPrec))
-	for bigAbs(term).Cmp(maxTruncError) >= 0 {
+	sign := int64(1)
+	divisor := new(big.Int)
+	return sumPrescaledSeries(p, calcPrec, new(big.Int).Set(power), func(term *big.Int) {
 		n += 2
-		power = bigDiv(power, isq)
+		power.Div(power, isq)
 		sign = -sign
-
-		term = bigDiv(power, bigMul(big.NewInt(sign), big.NewInt(n)))
-		sum = bigAdd(sum, term)
-	}
-	return scale(sum, calcPrec-p)
+		term.Div(power, divisor.SetInt64(sign*n))
+	})
 }
 
 func (c *integralArctan) asConstruction() string {
@@ -804,6 +1452,104 @@ func Sqrt(c Real) Real {
 	return newPrescaledSqrt(c)
 }
 
+// NthRoot computes the real n-th root of c, for n >= 1, or nil otherwise.
+// Like Pow, a negative c only has a real root when n is odd, e.g., the cube
+// root of -8 is -2; otherwise, nil is returned. The sign of c is taken from
+// SignExact, and when it cannot decide, the root is built anyway, so that
+// the sign of a c very close to zero is only examined on approximation.
+func NthRoot(c Real, n int) Real {
+	if n < 1 {
+		return nil
+	}
+	if n == 1 {
+		return c
+	}
+
+	if sign, ok := SignExact(c); ok {
+		switch {
+		case sign == 0:
+			return Zero()
+		case sign < 0 && n%2 == 0:
+			return nil
+		}
+	}
+	if n == 2 {
+		return Sqrt(c)
+	}
+
+	return newNthRoot(c, n)
+}
+
+// nthRoot is the real n-th root of r, for n >= 3, where r is non-negative
+// when n is even.
+type nthRoot struct {
+	precisionTracker
+	r Real
+	n int
+}
+
+func newNthRoot(c Real, n int) Real {
+	return &nthRoot{
+		r: c,
+		n: n,
+	}
+}
+
+// nthRootGuard is the number of guard bits of the integer root computed by
+// nthRoot.approximate.
+const nthRootGuard = 3
+
+// approximate computes the integer n-th root of an approximation of r at
+// precision n(p-g), which is r^(1/n) at precision p-g. Since the n-th root is
+// Hölder continuous, |a^(1/n) - b^(1/n)| <= |a - b|^(1/n), the error of one
+// unit in the approximation of r is at most one unit in its root, and so the
+// root is within two units before the guard bits are rounded off.
+//
+// As for the square root, an r below 2^(n(p-g)) has a root below 2^(p-g+1),
+// which rounds to zero; otherwise, the approximation of r has its sign.
+func (c *nthRoot) approximate(p int) *big.Int {
+	pn := c.n * (p - nthRootGuard)
+	if mr := msd(c.r, pn); mr <= pn {
+		return big.NewInt(0)
+	}
+
+	t := Approximate(c.r, pn)
+	if t.Sign() >= 0 {
+		return scale(bigNthRoot(t, c.n), -nthRootGuard)
+	}
+	if c.n%2 == 0 {
+		return nil
+	}
+	return bigNeg(scale(bigNthRoot(bigNeg(t), c.n), -nthRootGuard))
+}
+
+func (c *nthRoot) asConstruction() string {
+	return fmt.Sprintf("NthRoot(%s, %d)", c.r.asConstruction(), c.n)
+}
+
+// bigNthRoot computes the floor of the n-th root of t >= 0 with Newton's
+// method, starting from above the root.
+func bigNthRoot(t *big.Int, n int) *big.Int {
+	if t.Cmp(big.NewInt(2)) < 0 {
+		return new(big.Int).Set(t)
+	}
+
+	bn := big.NewInt(int64(n))
+	bn1 := big.NewInt(int64(n - 1))
+	x := bigLsh(big.NewInt(1), uint((t.BitLen()+n-1)/n))
+	for {
+		// y = ((n-1)x + t/x^(n-1)) / n
+		y := new(big.Int).Exp(x, bn1, nil)
+		y.Quo(t, y)
+		y.Add(y, new(big.Int).Mul(bn1, x))
+		y.Quo(y, bn)
+		if y.Cmp(x) >= 0 {
+			return x
+		}
+		x = y
+	}
+}
+
 type prescaledSqrt struct {
 	precisionTracker
 	r Real
@@ -847,23 +1593,44 @@ func (c *prescaledSqrt) asConstruction() string {
 }
 
 // Cosine computes the cosine of c.
+//
+// The argument is first reduced by multiples of π, using
+// cos(c - kπ) = (-1)^k cos(c), and then by halving it until it is small
+// enough for the series, using cos(c) = 2cos^2(c/2) - 1. Both reductions are
+// applied iteratively.
 func Cosine(c Real) Real {
-	rough := Approximate(c, -1)
-	if rough.CmpAbs(big.NewInt(6)) >= 0 {
+	negate := false
+	for rough := Approximate(c, -1); rough.CmpAbs(big.NewInt(6)) >= 0; rough = Approximate(c, -1) {
 		mult := bigDiv(rough, big.NewInt(6))
-		adj := Multiply(Pi(), FromBigInt(mult))
 		if bigBitAnd(mult, big.NewInt(1)).Sign() != 0 {
-			return Negate(Cosine(Subtract(c, adj)))
+			negate = !negate
 		}
 
-		return Cosine(Subtract(c, adj))
+		c = Subtract(c, Multiply(Pi(), FromBigInt(mult)))
 	}
 
-	if rough.CmpAbs(big.NewInt(2)) >= 0 {
-		return Subtract(ShiftLeft(Square(Cosine(ShiftRight(c, 1))), 1), One())
+	// rough approximation of c/2^k at precision -1 is that of c at k-1
+	k := 0
+	for Approximate(c, k-1).CmpAbs(big.NewInt(2)) >= 0 {
+		k++
 	}
 
-	return newPrescaledCosine(c)
+	r := newPrescaledCosine(ShiftRight(c, k))
+	for i := 0; i < k; i++ {
+		r = Subtract(ShiftLeft(Square(r), 1), One())
+	}
+
+	if negate {
+		return Negate(r)
+	}
+	return r
+}
+
+// ReduceMod2Pi computes the angle equivalent to c in [0, 2π), by subtracting
+// the integer multiple of τ given by Floor(c / τ). Like Floor, an angle that
+// is indistinguishable from a multiple of τ is reduced to zero.
+func ReduceMod2Pi(c Real) Real {
+	return Subtract(c, Multiply(Tau(), Floor(Divide(c, Tau()))))
 }
 
 // Sine computes the sine of c, using the identity `sin(c) = cos(π/2 - c)`.
@@ -899,25 +1666,24 @@ func (c *prescaledCosine) approximate(p int) *big.Int {
 		return big.NewInt(0)
 	}
 
-	iters := -p/2 - 2
-	calcPrec := p - boundLog2(2*iters) - 4
+	// the number of terms is bounded the same way as for the exponential, of
+	// which this is every other term; -p/2-2, as used previously, goes
+	// negative at coarse precisions, and only worked because boundLog2 takes
+	// the absolute value
+	calcPrec := seriesPrecision(p, -p/2+2)
 	opPrec := p - 3
 	opAppr := Approximate(c.r, opPrec)
 
-	term := bigLsh(big.NewInt(1), uint(-calcPrec))
-	sum := term
+	// ±x^n / n!, for even n, starting with 1; the previous term is multiplied
+	// by x twice, rather than by a rounded x^2
 	n := int64(0)
-	maxTruncError := bigLsh(big.NewInt(1), uint(p-4-calcPrec))
-	for bigAbs(term).Cmp(maxTruncError) >= 0 {
+	divisor := new(big.Int)
+	return sumPrescaledSeries(p, calcPrec, bigLsh(big.NewInt(1), uint(-calcPrec)), func(term *big.Int) {
 		n += 2
-
-		term = scale(bigMul(term, opAppr), opPrec)
-		term = scale(bigMul(term, opAppr), opPrec) // [sic]
-		term = bigDiv(term, big.NewInt(-n*(n-1)))
-		sum = bigAdd(sum, term)
-	}
-
-	return scale(sum, calcPrec-p)
+		scaleInto(term, term.Mul(term, opAppr), opPrec)
+		scaleInto(term, term.Mul(term, opAppr), opPrec)
+		term.Div(term, divisor.SetInt64(-n*(n-1)))
+	})
 }
 
 func (c *prescaledCosine) asConstruction() string {
@@ -925,15 +1691,64 @@ func (c *prescaledCosine) asConstruction() string {
 }
 
 // Pow computes the power c^n.
+//
+// When c is negative, n must be identifiable as a rational with an odd
+// denominator, in which case the real root of the magnitude is taken and the
+// sign is applied, e.g., (-8)^(1/3) = -2. Otherwise, nil is returned, since
+// there is no real result.
+//
+// The sign of c is taken from SignExact, and only when it cannot decide is
+// c refined down to the precision that the inverse searches to, so that a
+// tiny negative c such as -2^-200 is recognized as negative.
 func Pow(c, n Real) Real {
+	sign, ok := SignExact(c)
+	if !ok {
+		sign = deepSign(c)
+	}
+	if sign < 0 {
+		return negativePow(c, n)
+	}
+
 	return Exp(Multiply(Ln(c), n))
 }
 
+// negativePow computes c^n for a negative c and rational n with an odd
+// denominator, using c^n = (-1)^n * |c|^n.
+func negativePow(c, n Real) Real {
+	r, ok, _ := Identify(n)
+	if !ok || r.Denom().Bit(0) == 0 {
+		return nil
+	}
+
+	mag := Pow(Negate(c), n)
+	if r.Num().Bit(0) == 1 {
+		return Negate(mag)
+	}
+	return mag
+}
+
 // Pow10 computes the power 10^n.
 func Pow10(n Real) Real {
 	return Pow(Ten(), n)
 }
 
+// Pow10Int computes the power 10^n exactly, as an integer when n >= 0, or as
+// the inverse of one when n < 0. Unlike Pow10, it does not go through Ln and
+// Exp, so the result can be identified as rational.
+func Pow10Int(n int) Real {
+	if n < 0 {
+		return Inverse(Pow10Int(-n))
+	}
+
+	return FromBigInt(bigExp(big.NewInt(10), big.NewInt(int64(n)), nil))
+}
+
+// Pow2Int computes the power 2^n exactly, as a shift of one. Since shifts of
+// integers are identified as rational, so is the result.
+func Pow2Int(n int) Real {
+	return ShiftLeft(One(), n)
+}
+
 type named struct {
 	Real
 	Name string
@@ -950,6 +1765,27 @@ func (c *named) asConstruction() string {
 	return fmt.Sprintf("Named(%q, %s)", c.Name, c.Real.asConstruction())
 }
 
+// Named attaches a name to c, which is shown by AsConstruction and Pretty and
+// returned by ConstructiveName, so that intermediate results can be labeled
+// for debugging. The name does not otherwise change how c is computed or
+// identified.
+func Named(name string, c Real) Real {
+	return newNamed(name, c)
+}
+
+// Unwrap strips any names from c, returning the underlying construction.
+// Optimizations that rely on the identity or type of a node should look at
+// the unwrapped node, so that naming a value does not defeat them.
+func Unwrap(c Real) Real {
+	for {
+		n, ok := c.(*named)
+		if !ok {
+			return c
+		}
+		c = n.Real
+	}
+}
+
 // ConstructiveName returns the name of the constructive Real number c,
 // if it has one. The second return value indicates whether a name was found.
 func ConstructiveName(c Real) (string, bool) {
@@ -959,6 +1795,34 @@ func ConstructiveName(c Real) (string, bool) {
 	return "", false
 }
 
+// NamedNodes collects every named node in the construction of c, including c
+// itself, keyed by name. When different nodes share a name, the first one
+// found in a depth-first walk is kept.
+func NamedNodes(c Real) map[string]Real {
+	nodes := map[string]Real{}
+	seen := map[Real]bool{}
+
+	var walk func(Real)
+	walk = func(c Real) {
+		if c == nil || seen[c] {
+			return
+		}
+
+		seen[c] = true
+		if n, ok := c.(*named); ok {
+			if _, dup := nodes[n.Name]; !dup {
+				nodes[n.Name] = c
+			}
+		}
+		for _, o := range operands(c) {
+			walk(o)
+		}
+	}
+	walk(c)
+
+	return nodes
+}
+
 // ContinuedFraction64 computes the continued fraction from the given
 // slice of int64 values.
 func ContinuedFraction64(fracs []int64) Real {
@@ -988,3 +1852,119 @@ func ContinuedFraction(fracs []Real) Real {
 
 	return c
 }
+
+// GeneralizedContinuedFraction computes the generalized continued fraction
+//
+// b₀ + a₁/(b₁ + a₂/(b₂ + a₃/(b₃ + ...)))
+//
+// from the given partial numerators a and partial denominators b, where
+// a[i-1] holds aᵢ. The slice a must be exactly one element shorter than b;
+// otherwise, nil is returned.
+func GeneralizedContinuedFraction(a, b []Real) Real {
+	if len(b) == 0 {
+		return Zero()
+	}
+	if len(a) != len(b)-1 {
+		return nil
+	}
+
+	c := b[len(b)-1]
+	for i := len(b) - 2; i >= 0; i-- {
+		c = Add(b[i], Divide(a[i], c))
+	}
+
+	return c
+}
+
+// ToContinuedFraction computes the first `terms` partial quotients of the
+// simple continued fraction expansion of c, by repeatedly taking the floor
+// and inverting the fractional part.
+//
+// The expansion is computed from an approximation of c, bracketed by its
+// error bounds, and the precision is refined a bounded number of times until
+// both bounds agree on every quotient. When c is at (or very near) an exact
+// integer boundary at some step, e.g., when c is rational and its expansion
+// terminates, the bounds never agree and the quotients from that step onward
+// are reported from the approximation itself, which may be imprecise. Fewer
+// than `terms` quotients are returned when the approximation's expansion
+// terminates or a quotient does not fit in an int64.
+func ToContinuedFraction(c Real, terms int) []int64 {
+	if c == nil || terms <= 0 {
+		return nil
+	}
+
+	p := -64 - 4*terms
+	for i := 0; i < 4; i++ {
+		if fracs, ok := boundedContinuedFraction(c, p, terms); ok {
+			return fracs
+		}
+		p *= 2
+	}
+
+	n := Approximate(c, p)
+	if n == nil {
+		return nil
+	}
+
+	return ratContinuedFraction(new(big.Rat).SetFrac(n, bigLsh(big.NewInt(1), uint(-p))), terms)
+}
+
+// boundedContinuedFraction expands c approximated at precision p, returning
+// false if the lower and upper bounds of the approximation disagree on any of
+// the first `terms` partial quotients.
+func boundedContinuedFraction(c Real, p, terms int) ([]int64, bool) {
+	n := Approximate(c, p)
+	if n == nil {
+		return nil, false
+	}
+
+	denom := bigLsh(big.NewInt(1), uint(-p))
+	lo := new(big.Rat).SetFrac(bigSub(n, big.NewInt(1)), denom)
+	hi := new(big.Rat).SetFrac(bigAdd(n, big.NewInt(1)), denom)
+
+	fracs := make([]int64, 0, terms)
+	for len(fracs) < terms {
+		a := ratFloor(lo)
+		if a.Cmp(ratFloor(hi)) != 0 || !a.IsInt64() {
+			return nil, false
+		}
+
+		ar := new(big.Rat).SetInt(a)
+		flo := new(big.Rat).Sub(lo, ar)
+		fhi := new(big.Rat).Sub(hi, ar)
+		if flo.Sign() == 0 {
+			return nil, false
+		}
+
+		fracs = append(fracs, a.Int64())
+		lo, hi = fhi.Inv(fhi), flo.Inv(flo)
+	}
+
+	return fracs, true
+}
+
+// ratContinuedFraction expands the rational r into at most `terms` partial
+// quotients.
+func ratContinuedFraction(r *big.Rat, terms int) []int64 {
+	fracs := make([]int64, 0, terms)
+	for len(fracs) < terms {
+		a := ratFloor(r)
+		if !a.IsInt64() {
+			break
+		}
+
+		fracs = append(fracs, a.Int64())
+		f := new(big.Rat).Sub(r, new(big.Rat).SetInt(a))
+		if f.Sign() == 0 {
+			break
+		}
+		r = f.Inv(f)
+	}
+
+	return fracs
+}
+
+// ratFloor computes the floor of a rational number.
+func ratFloor(r *big.Rat) *big.Int {
+	return bigDiv(r.Num(), r.Denom())
+}
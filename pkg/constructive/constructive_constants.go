@@ -25,6 +25,11 @@ var Pi = sync.OnceValue(func() Real {
 	return newNamed("π", Multiply(FromInt(4), Add(m1, Add(m2, m3))))
 })
 
+// Tau calculates τ = 2π, the number of radians in a full turn.
+var Tau = sync.OnceValue(func() Real {
+	return newNamed("τ", ShiftLeft(Pi(), 1))
+})
+
 // Phi calculates the golden ratio: φ = (1 + √5) / 2
 var Phi = sync.OnceValue(func() Real {
 	return newNamed("φ", Divide(Add(FromInt(1), Sqrt(FromInt(5))), FromInt(2)))
@@ -35,6 +40,12 @@ var Sqrt2 = sync.OnceValue(func() Real {
 	return newNamed("√2", Sqrt(FromInt(2)))
 })
 
+// Apery calculates Apéry's constant ζ(3) using the series:
+// ζ(3) = 5/2 * Σ (-1)^(k+1) / (k^3 * C(2k, k))
+var Apery = sync.OnceValue(func() Real {
+	return newNamed("ζ(3)", newAperySeries())
+})
+
 // Zero represents the constant 0.
 var Zero = sync.OnceValue(func() Real {
 	return newNamed("0", FromInt(0))
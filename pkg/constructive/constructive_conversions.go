@@ -9,3 +9,23 @@ func RadiansToDegrees(radians Real) Real {
 	// 180 / π * radians
 	return Multiply(Divide(FromInt(180), Pi()), radians)
 }
+
+func GradiansToRadians(gradians Real) Real {
+	// π / 200 * gradians
+	return Multiply(Divide(Pi(), FromInt(200)), gradians)
+}
+
+func RadiansToGradians(radians Real) Real {
+	// 200 / π * radians
+	return Multiply(Divide(FromInt(200), Pi()), radians)
+}
+
+func TurnsToRadians(turns Real) Real {
+	// τ * turns
+	return Multiply(Tau(), turns)
+}
+
+func RadiansToTurns(radians Real) Real {
+	// radians / τ
+	return Divide(radians, Tau())
+}
@@ -1,9 +1,13 @@
 package constructive
 
 import (
+	"context"
 	"math"
 	"math/big"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -63,6 +67,13 @@ func TestApproximate(t *testing.T) {
 	}
 }
 
+func TestInverse_Negative(t *testing.T) {
+	assertEqualAtPrecision(t, FromRat(-1, 2), Inverse(FromInt(-2)), -100)
+	assertEqualAtPrecision(t, FromRat(-1, 3), Divide(One(), FromInt(-3)), -100)
+	assertEqualAtPrecision(t, FromRat(7, 3), Divide(FromInt(-7), FromInt(-3)), -100)
+	assertEqualAtPrecision(t, Negate(Inverse(Pi())), Inverse(Negate(Pi())), -100)
+}
+
 type cmpTest struct {
 	inputA   Real
 	inputB   Real
@@ -90,6 +101,99 @@ func TestCmp(t *testing.T) {
 	}
 }
 
+type withinTest struct {
+	inputA   Real
+	inputB   Real
+	tol      Real
+	expected bool
+}
+
+var withinAbsTests = []withinTest{
+	{Pi(), FromFloat64(3.14159), FromFloat64(0.001), true},
+	{Pi(), FromFloat64(3.14159), FromFloat64(1e-6), false},
+	{FromInt(1), FromInt(2), FromInt(1), true},
+	{FromInt(2), FromInt(1), FromRat(1, 2), false},
+	{Sqrt(FromInt(2)), Sqrt(FromInt(2)), Zero(), true},
+}
+
+func TestWithinAbs(t *testing.T) {
+	for _, test := range withinAbsTests {
+		assert.Equal(t, test.expected, WithinAbs(test.inputA, test.inputB, test.tol), "WithinAbs(%s, %s, %s)", AsConstruction(test.inputA), AsConstruction(test.inputB), AsConstruction(test.tol))
+	}
+}
+
+var withinRelTests = []withinTest{
+	{FromInt(1000000), FromInt(1000001), FromFloat64(1e-5), true},
+	{FromInt(1000000), FromInt(1000001), FromFloat64(1e-7), false},
+	{FromRat(1, 1000000), FromRat(2, 1000000), FromFloat64(0.1), false},
+	{Negate(E()), Negate(FromFloat64(2.718)), FromFloat64(0.001), true},
+	{FromInt(10), FromInt(11), FromRat(1, 11), true},
+}
+
+func TestWithinRel(t *testing.T) {
+	for _, test := range withinRelTests {
+		assert.Equal(t, test.expected, WithinRel(test.inputA, test.inputB, test.tol), "WithinRel(%s, %s, %s)", AsConstruction(test.inputA), AsConstruction(test.inputB), AsConstruction(test.tol))
+	}
+}
+
+type nearestIntegerTest struct {
+	input    Real
+	expected int64
+	within   bool
+}
+
+var nearestIntegerTests = []nearestIntegerTest{
+	{Multiply(Sqrt2(), Sqrt2()), 2, true},
+	{Pi(), 3, false},
+	{E(), 3, false},
+	{Negate(FromRat(9, 4)), -2, false},
+	{Subtract(FromInt(5), ShiftRight(One(), 60)), 5, true},
+	{Exp(Ln(FromInt(7))), 7, true},
+	{Zero(), 0, true},
+	{FromRat(12, 5), 2, false},
+	{FromRat(-12, 5), -2, false},
+	{FromRat(13, 5), 3, false},
+	{FromRat(-13, 5), -3, false},
+	{FromRat(1, 3), 0, false},
+	{FromRat(7, 10), 1, false},
+}
+
+func TestNearestInteger(t *testing.T) {
+	for _, test := range nearestIntegerTests {
+		n, ok := NearestInteger(test.input, -50)
+		assert.Equal(t, test.expected, n.Int64(), AsConstruction(test.input))
+		assert.Equal(t, test.within, ok, AsConstruction(test.input))
+	}
+
+	// within a looser tolerance, π is close enough to 3
+	n, ok := NearestInteger(Pi(), 0)
+	assert.Equal(t, int64(3), n.Int64())
+	assert.True(t, ok)
+
+	for _, p := range []int{-2, -1, 0, 2} {
+		n, ok = NearestInteger(FromRat(12, 5), p)
+		assert.Equal(t, int64(2), n.Int64(), "precision %d", p)
+		assert.Equal(t, p >= -1, ok, "precision %d", p)
+	}
+}
+
+func TestCmpSchedule(t *testing.T) {
+	a := Add(FromFloat64(1), ShiftRight(FromInt(1), 200))
+	b := FromInt(1)
+
+	assert.Equal(t, 1, CmpSchedule(a, b, -210, 2))
+	assert.Equal(t, -1, CmpSchedule(b, a, -4, 10))
+	assert.Equal(t, 1, Cmp(a, b))
+
+	// the first comparison is already at a fine enough precision
+	c := &countingReal{r: a}
+	assert.Equal(t, 1, CmpSchedule(c, b, -300, 2))
+	assert.Equal(t, int32(1), c.count.Load())
+
+	assert.Equal(t, 0, CmpSchedule(a, b, 0, 2))
+	assert.Equal(t, 0, CmpSchedule(a, b, -20, 1))
+}
+
 type preciseCmpTest struct {
 	inputA   Real
 	inputB   Real
@@ -234,7 +338,7 @@ func TestText(t *testing.T) {
 
 	ninth := Inverse(nine)
 	assert.Equal(t, "0.11111111111111111111", Text(ninth, 20, 10))
-	assert.Equal(t, "0.00011100011100011101", Text(ninth, 20, 2))
+	assert.Equal(t, "0.00011100011100011100", Text(ninth, 20, 2))
 	assert.Equal(t, "0.01301301301301301302", Text(ninth, 20, 4))
 	assert.Equal(t, "0.07070707070707070707", Text(ninth, 20, 8))
 	assert.Equal(t, "0.14000000000000000000", Text(ninth, 20, 12))
@@ -445,3 +549,1901 @@ func TestAsConstruction(t *testing.T) {
 		})
 	}
 }
+
+func TestGeneralizedContinuedFraction(t *testing.T) {
+	// e = 2 + 1/(1 + 1/(2 + 2/(3 + 3/(4 + ...))))
+	ea := []Real{One()}
+	eb := []Real{Two(), One()}
+	for n := 1; n < 40; n++ {
+		ea = append(ea, FromInt(n))
+		eb = append(eb, FromInt(n+1))
+	}
+	assertEqualAtPrecision(t, E(), GeneralizedContinuedFraction(ea, eb), -50)
+
+	// tan(1) = 1/(1 - 1/(3 - 1/(5 - ...)))
+	ta := []Real{One()}
+	tb := []Real{Zero(), One()}
+	for n := 1; n < 20; n++ {
+		ta = append(ta, FromInt(-1))
+		tb = append(tb, FromInt(2*n+1))
+	}
+	assertEqualAtPrecision(t, Tangent(One()), GeneralizedContinuedFraction(ta, tb), -50)
+
+	// a simple continued fraction is a generalized one with unit numerators
+	assertEqualAtPrecision(t, ContinuedFraction64([]int64{2, 1, 3, 4}), GeneralizedContinuedFraction(FromIntSlice([]int{1, 1, 1}), FromIntSlice([]int{2, 1, 3, 4})), -100)
+
+	assertEqualAtPrecision(t, Zero(), GeneralizedContinuedFraction(nil, nil), -100)
+	assert.Nil(t, GeneralizedContinuedFraction(FromIntSlice([]int{1, 1}), FromIntSlice([]int{1, 1})))
+}
+
+func TestToContinuedFraction(t *testing.T) {
+	assert.Equal(t, []int64{1, 2, 2, 2, 2, 2, 2, 2, 2, 2}, ToContinuedFraction(Sqrt2(), 10))
+	assert.Equal(t, []int64{1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, ToContinuedFraction(Phi(), 10))
+	assert.Equal(t, []int64{3, 7, 15, 1, 292, 1, 1, 1, 2, 1}, ToContinuedFraction(Pi(), 10))
+	assert.Equal(t, []int64{-2, 1, 1, 2, 2, 2}, ToContinuedFraction(Negate(Sqrt2()), 6))
+
+	// 47/17 = [2; 1, 3, 4], but the final quotient sits on an exact integer
+	// boundary, so only the leading quotients are reliable
+	assert.Equal(t, []int64{2, 1, 3}, ToContinuedFraction(Divide(FromInt(47), FromInt(17)), 3))
+
+	assert.Nil(t, ToContinuedFraction(Pi(), 0))
+	assert.Nil(t, ToContinuedFraction(nil, 10))
+}
+
+func TestLambertW(t *testing.T) {
+	assertEqualAtPrecision(t, Zero(), LambertW(FromInt(0)), -50)
+	assertEqualAtPrecision(t, One(), LambertW(E()), -50)
+	assertEqualAtPrecision(t, FromInt(2), LambertW(Multiply(FromInt(2), Square(E()))), -50)
+	// W(-ln(2)/2) = -ln(2)
+	assertEqualAtPrecision(t, Negate(Ln2()), LambertW(Negate(ShiftRight(Ln2(), 1))), -50)
+	// Ω = W(1) satisfies Ω = e^-Ω
+	omega := LambertW(One())
+	assertEqualAtPrecision(t, omega, Exp(Negate(omega)), -100)
+	assert.Equal(t, "0.56714329040978387299996866221035554975381578718651", Text(omega, 50, 10))
+
+	w := LambertW(FromInt(1000000))
+	assertEqualAtPrecision(t, FromInt(1000000), Multiply(w, Exp(w)), -50)
+
+	assert.Nil(t, LambertW(FromInt(-1)))
+	assert.Nil(t, LambertW(FromFloat64(-0.37)))
+}
+
+func TestZeta(t *testing.T) {
+	assertEqualAtPrecision(t, Divide(Square(Pi()), FromInt(6)), Zeta(2), -100)
+	assertEqualAtPrecision(t, Divide(powInt(Pi(), 4), FromInt(90)), Zeta(4), -100)
+	assertEqualAtPrecision(t, Divide(powInt(Pi(), 6), FromInt(945)), Zeta(6), -100)
+	assertEqualAtPrecision(t, Apery(), Zeta(3), -40)
+	assertEqualAtPrecision(t, Apery(), Zeta(3), -200)
+
+	assert.Equal(t, "1.2020569031595942853997381615114499907649862923404988817922715553418382", Text(Apery(), 70, 10))
+	assert.Equal(t, "1.0369277551433699263313654864570341680570809195019128119741926779038036", Text(Zeta(5), 70, 10))
+
+	assert.Nil(t, Zeta(1))
+	assert.Nil(t, Zeta(0))
+}
+
+type identifyTest struct {
+	name     string
+	input    Real
+	expected *big.Rat
+}
+
+var identifyTests = []identifyTest{
+	{"integer", FromInt(5), big.NewRat(5, 1)},
+	{"rational", FromRat(22, 7), big.NewRat(22, 7)},
+	{"named", One(), big.NewRat(1, 1)},
+	{"sum", Add(FromRat(1, 2), FromRat(1, 3)), big.NewRat(5, 6)},
+	{"difference", Subtract(FromInt(3), FromInt(3)), big.NewRat(0, 1)},
+	{"shift left", ShiftLeft(FromInt(3), 4), big.NewRat(48, 1)},
+	{"shift right", ShiftRight(FromInt(3), 4), big.NewRat(3, 16)},
+	{"float", FromFloat64(0.375), big.NewRat(3, 8)},
+	{"abs", Abs(FromInt(-4)), big.NewRat(4, 1)},
+	{"max", Max(FromInt(-4), FromRat(1, 2)), big.NewRat(1, 2)},
+	{"zero times pi", Multiply(Pi(), Zero()), big.NewRat(0, 1)},
+	{"continued fraction", ContinuedFraction64([]int64{2, 1, 3, 4}), big.NewRat(47, 17)},
+}
+
+func TestIdentify(t *testing.T) {
+	for _, test := range identifyTests {
+		t.Run(test.name, func(t *testing.T) {
+			r, ok, err := Identify(test.input)
+			assert.NoError(t, err)
+			if assert.True(t, ok) {
+				assert.Equal(t, test.expected.String(), r.String())
+			}
+			assert.True(t, IsRational(test.input))
+		})
+	}
+
+	for _, c := range []Real{Pi(), E(), Sqrt2(), Add(FromInt(1), Pi()), Inverse(Zero())} {
+		_, ok, err := Identify(c)
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		assert.False(t, IsRational(c))
+	}
+
+	_, _, err := Identify(nil)
+	assert.ErrorIs(t, err, ErrNotConstructive)
+}
+
+func TestIdentify_SharedSubtrees(t *testing.T) {
+	// each level refers to the previous one twice, so that the expanded tree
+	// has 2^64 leaves, while each level adds only two distinct nodes
+	x, y := Pi(), FromRat(1, 3)
+	for i := 0; i < 64; i++ {
+		x = Multiply(x, Inverse(x))
+		y = Add(y, Negate(y))
+	}
+
+	assert.False(t, IsRational(x))
+	r, ok, _ := Identify(y)
+	if assert.True(t, ok) {
+		assert.Equal(t, 0, r.Sign())
+	}
+}
+
+func TestSubtractExact(t *testing.T) {
+	d, exact := SubtractExact(FromRat(1, 3), FromRat(1, 3))
+	assert.True(t, exact)
+	r, ok, _ := Identify(d)
+	assert.True(t, ok)
+	assert.Equal(t, 0, r.Sign())
+	sign, decidable := SignExact(d)
+	assert.Equal(t, 0, sign)
+	assert.True(t, decidable)
+
+	d, exact = SubtractExact(FromRat(1, 2), FromRat(1, 3))
+	assert.True(t, exact)
+	assertEqualAtPrecision(t, FromRat(1, 6), d, -100)
+
+	d, exact = SubtractExact(Pi(), FromInt(3))
+	assert.False(t, exact)
+	assertEqualAtPrecision(t, Subtract(Pi(), FromInt(3)), d, -100)
+}
+
+func TestCmpExact(t *testing.T) {
+	cmp, exact := CmpExact(FromRat(1, 3), FromRat(1, 2))
+	assert.Equal(t, -1, cmp)
+	assert.True(t, exact)
+
+	// differs from 1/3 by far less than 2^-100, but is decided exactly
+	cmp, exact = CmpExact(Add(FromRat(1, 3), ShiftRight(One(), 200)), Divide(One(), FromInt(3)))
+	assert.Equal(t, 1, cmp)
+	assert.True(t, exact)
+
+	cmp, exact = CmpExact(Subtract(FromRat(1, 3), FromRat(1, 3)), Zero())
+	assert.Equal(t, 0, cmp)
+	assert.True(t, exact)
+
+	cmp, exact = CmpExact(Pi(), E())
+	assert.Equal(t, 1, cmp)
+	assert.False(t, exact)
+
+	cmp, exact = CmpExact(Subtract(Pi(), Pi()), Zero())
+	assert.Equal(t, 0, cmp)
+	assert.False(t, exact)
+}
+
+type powerOfTwoTest struct {
+	input    Real
+	exponent int
+	ok       bool
+}
+
+var powerOfTwoTests = []powerOfTwoTest{
+	{FromInt(8), 3, true},
+	{FromInt(6), 0, false},
+	{One(), 0, true},
+	{ShiftLeft(One(), 100), 100, true},
+	{ShiftRight(One(), 3), -3, true},
+	{FromRat(1, 8), -3, true},
+	{FromRat(3, 8), 0, false},
+	{Multiply(FromInt(4), FromRat(1, 2)), 1, true},
+	{FromInt(-8), 0, false},
+	{Zero(), 0, false},
+	{Pi(), 0, false},
+}
+
+func TestIsPowerOfTwo(t *testing.T) {
+	for _, test := range powerOfTwoTests {
+		exponent, ok := IsPowerOfTwo(test.input)
+		assert.Equal(t, test.ok, ok, AsConstruction(test.input))
+		assert.Equal(t, test.exponent, exponent, AsConstruction(test.input))
+	}
+}
+
+type signExactTest struct {
+	name      string
+	input     Real
+	sign      int
+	decidable bool
+}
+
+var signExactTests = []signExactTest{
+	{"rational zero", Subtract(FromInt(3), FromInt(3)), 0, true},
+	{"tiny rational", ShiftRight(FromInt(-1), 200), -1, true},
+	{"rational", FromRat(22, 7), 1, true},
+	{"irrational", Negate(Pi()), -1, true},
+	{"irrational zero", Subtract(Pi(), Pi()), 0, false},
+}
+
+func TestSignExact(t *testing.T) {
+	for _, test := range signExactTests {
+		t.Run(test.name, func(t *testing.T) {
+			sign, decidable := SignExact(test.input)
+			assert.Equal(t, test.sign, sign)
+			assert.Equal(t, test.decidable, decidable)
+		})
+	}
+}
+
+func TestPow_NegativeBase(t *testing.T) {
+	assertEqualAtPrecision(t, FromInt(-2), Pow(FromInt(-8), FromRat(1, 3)), -100)
+	assertEqualAtPrecision(t, FromInt(4), Pow(FromInt(-8), FromRat(2, 3)), -100)
+	assertEqualAtPrecision(t, FromInt(-8), Pow(FromInt(-2), FromInt(3)), -100)
+	assertEqualAtPrecision(t, FromInt(16), Pow(FromInt(-2), FromInt(4)), -100)
+	assertEqualAtPrecision(t, FromRat(-1, 2), Pow(FromInt(-8), FromRat(-1, 3)), -100)
+
+	// negative bases too close to zero to tell from a rough approximation
+	assertEqualAtPrecision(t, Negate(Inverse(NthRoot(FromInt(1024), 3))), Pow(FromRat(-1, 1024), FromRat(1, 3)), -100)
+	assertEqualAtPrecision(t, FromRat(-1, 1<<30), Pow(FromRat(-1, 1<<10), FromInt(3)), -100)
+	assertEqualAtPrecision(t, FromRat(-1, 1024), Pow(FromRat(-1, 1<<30), FromRat(1, 3)), -100)
+	assert.Nil(t, Pow(FromRat(-1, 1024), FromRat(1, 2)))
+
+	// a rational base is decided exactly, and other bases only when SignExact
+	// cannot decide them
+	assertEqualAtPrecision(t, Negate(ShiftRight(One(), 1000)), Pow(Negate(ShiftRight(One(), 3000)), FromRat(1, 3)), -1100)
+	assertEqualAtPrecision(t, Negate(ShiftRight(One(), 100)), Pow(Negate(ShiftRight(Multiply(Exp(FromInt(-1)), E()), 300)), FromRat(1, 3)), -200)
+
+	assert.Nil(t, Pow(FromInt(-4), FromRat(1, 2)))
+	assert.Nil(t, Pow(FromInt(-2), Pi()))
+}
+
+func TestSignum_Real(t *testing.T) {
+	assertEqualAtPrecision(t, One(), Signum(Pi()), -100)
+	assertEqualAtPrecision(t, FromInt(-1), Signum(Negate(E())), -100)
+	assertEqualAtPrecision(t, Zero(), Signum(Subtract(Pi(), Pi())), -100)
+	assertEqualAtPrecision(t, One(), Signum(ShiftRight(One(), 90)), -100)
+	// indistinguishable from zero at the internal precision
+	assertEqualAtPrecision(t, Zero(), Signum(ShiftRight(One(), 200)), -100)
+	assertEqualAtPrecision(t, FromInt(-3), Multiply(Signum(FromInt(-7)), FromInt(3)), -100)
+}
+
+func TestHeaviside(t *testing.T) {
+	assertEqualAtPrecision(t, One(), Heaviside(FromInt(5)), -100)
+	assertEqualAtPrecision(t, Zero(), Heaviside(Negate(Pi())), -100)
+	assertEqualAtPrecision(t, FromRat(1, 2), Heaviside(Zero()), -100)
+	assertEqualAtPrecision(t, FromRat(1, 2), Heaviside(Subtract(E(), E())), -100)
+}
+
+func TestReLU(t *testing.T) {
+	assertEqualAtPrecision(t, Zero(), ReLU(Negate(Pi())), -100)
+	assertEqualAtPrecision(t, Pi(), ReLU(Pi()), -100)
+	assertEqualAtPrecision(t, Zero(), ReLU(Zero()), -100)
+	assertEqualAtPrecision(t, FromRat(1, 3), ReLU(FromRat(1, 3)), -100)
+}
+
+func TestExpErr(t *testing.T) {
+	r, err := ExpErr(FromInt(1000))
+	assert.NoError(t, err)
+	assertEqualAtPrecision(t, FromInt(1000), Ln(r), -100)
+
+	_, err = ExpErr(Pow10(FromInt(6)))
+	assert.NoError(t, err)
+
+	_, err = ExpErr(Pow10(FromInt(100)))
+	assert.ErrorIs(t, err, ErrExpRange)
+	_, err = ExpErr(Negate(Pow10(FromInt(100))))
+	assert.ErrorIs(t, err, ErrExpRange)
+}
+
+func TestLogistic(t *testing.T) {
+	assertEqualAtPrecision(t, FromRat(1, 2), Logistic(Zero()), -100)
+	assertEqualAtPrecision(t, Inverse(Add(One(), Inverse(E()))), Logistic(One()), -100)
+	assertEqualAtPrecision(t, One(), Add(Logistic(Pi()), Logistic(Negate(Pi()))), -100)
+
+	assertEqualAtPrecision(t, One(), Logistic(FromInt(1000)), -50)
+	assertEqualAtPrecision(t, Zero(), Logistic(FromInt(-1000)), -50)
+	assert.Equal(t, 1, Sign(Logistic(FromInt(-1000))))
+}
+
+func TestSoftplus(t *testing.T) {
+	assertEqualAtPrecision(t, Ln(FromInt(2)), Softplus(Zero()), -100)
+	assertEqualAtPrecision(t, Ln(Add(One(), E())), Softplus(One()), -100)
+	assertEqualAtPrecision(t, Ln(Add(One(), Inverse(E()))), Softplus(FromInt(-1)), -100)
+
+	// softplus(c) - softplus(-c) = c
+	assertEqualAtPrecision(t, Pi(), Subtract(Softplus(Pi()), Softplus(Negate(Pi()))), -100)
+
+	assertEqualAtPrecision(t, FromInt(1000), Softplus(FromInt(1000)), -50)
+	assertEqualAtPrecision(t, Zero(), Softplus(FromInt(-100)), -50)
+	assert.Equal(t, 1, Sign(Softplus(FromInt(-100))))
+	assertEqualAtPrecision(t, Exp(FromInt(-100)), Softplus(FromInt(-100)), -200)
+}
+
+func TestSum(t *testing.T) {
+	assertEqualAtPrecision(t, Zero(), Sum(), -100)
+	assertEqualAtPrecision(t, Pi(), Sum(Pi()), -100)
+	assertEqualAtPrecision(t, FromInt(55), Sum(FromIntSlice([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})...), -100)
+	assertEqualAtPrecision(t, Add(Pi(), E()), Sum(Pi(), FromInt(1), E(), FromInt(-1)), -100)
+}
+
+func TestDot(t *testing.T) {
+	assertEqualAtPrecision(t, FromInt(32), Dot(FromIntSlice([]int{1, 2, 3}), FromIntSlice([]int{4, 5, 6})), -100)
+	assertEqualAtPrecision(t, Zero(), Dot(nil, nil), -100)
+	assertEqualAtPrecision(t, FromInt(2), Dot([]Real{Sqrt2(), One()}, []Real{Sqrt2(), Zero()}), -100)
+	assert.Panics(t, func() {
+		Dot(FromIntSlice([]int{1, 2}), FromIntSlice([]int{1}))
+	})
+}
+
+func TestNorm(t *testing.T) {
+	assertEqualAtPrecision(t, FromInt(5), Norm(FromIntSlice([]int{3, 4})), -100)
+	assertEqualAtPrecision(t, FromInt(3), Norm(FromIntSlice([]int{1, 2, 2})), -100)
+	assertEqualAtPrecision(t, Sqrt2(), Norm([]Real{One(), Negate(One())}), -100)
+}
+
+func TestNormalize(t *testing.T) {
+	v := Normalize(FromIntSlice([]int{3, 4}))
+	assertEqualAtPrecision(t, FromRat(3, 5), v[0], -100)
+	assertEqualAtPrecision(t, FromRat(4, 5), v[1], -100)
+	assertEqualAtPrecision(t, One(), Norm(v), -100)
+
+	assertEqualAtPrecision(t, One(), Norm(Normalize([]Real{Pi(), E(), Phi()})), -100)
+}
+
+func TestFindRoot(t *testing.T) {
+	ctx := context.Background()
+
+	sqrt2, err := FindRoot(func(x Real) Real {
+		return Subtract(Square(x), FromInt(2))
+	}, FromInt(1), FromInt(2), ctx)
+	if assert.NoError(t, err) {
+		assertEqualAtPrecision(t, Sqrt2(), sqrt2, -50)
+		assertEqualAtPrecision(t, Sqrt2(), sqrt2, -100)
+	}
+
+	// bracket ends may be given in either order
+	pi, err := FindRoot(Sine, FromInt(4), FromInt(3), ctx)
+	if assert.NoError(t, err) {
+		assertEqualAtPrecision(t, Pi(), pi, -50)
+	}
+
+	// the root is exactly a dyadic midpoint
+	half, err := FindRoot(func(x Real) Real {
+		return Subtract(x, FromRat(1, 2))
+	}, FromInt(0), FromInt(1), ctx)
+	if assert.NoError(t, err) {
+		assertEqualAtPrecision(t, FromRat(1, 2), half, -50)
+	}
+
+	_, err = FindRoot(func(x Real) Real {
+		return Add(Square(x), One())
+	}, FromInt(-1), FromInt(1), ctx)
+	assert.ErrorIs(t, err, ErrRootNotBracketed)
+
+	limited, err := FindRoot(func(x Real) Real {
+		return Subtract(Square(x), FromInt(2))
+	}, FromInt(1), FromInt(2), WithPrecisionLimit(ctx, 40))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "1.414214", Text(limited, 6, 10))
+		assert.Equal(t, "<undefined: precision overflow>", Text(limited, 20, 10))
+	}
+}
+
+func TestIntegrate(t *testing.T) {
+	assertEqualAtPrecision(t, FromInt(9), Integrate(Square, FromInt(0), FromInt(3), 2), -100)
+	// x³ from 1 to 2 is 15/4, exact for Simpson's rule
+	assertEqualAtPrecision(t, FromRat(15, 4), Integrate(func(x Real) Real {
+		return Multiply(x, Square(x))
+	}, FromInt(1), FromInt(2), 4), -100)
+	// sin from 0 to π is 2, with O(h⁴) error
+	assertEqualAtPrecision(t, FromInt(2), Integrate(Sine, Zero(), Pi(), 64), -20)
+
+	assert.Nil(t, Integrate(Square, FromInt(0), FromInt(3), 3))
+	assert.Nil(t, Integrate(Square, FromInt(0), FromInt(3), 0))
+}
+
+func TestKnownMSD(t *testing.T) {
+	for _, c := range []Real{FromInt(8), FromInt(-8), Inverse(FromInt(8)), Pi(), Negate(E()), Pow(Pi(), E())} {
+		for _, p := range []int{-10, -100, -200} {
+			_ = Approximate(c, p)
+			tr := c.tracker()
+			assert.Equal(t, tr.computeMSD(), knownMSD(c), "%s at %d", AsConstruction(c), p)
+			assert.True(t, tr.msdValid)
+			// memoized value survives repeated queries
+			assert.Equal(t, tr.computeMSD(), knownMSD(c))
+		}
+	}
+
+	c := FromInt(1000)
+	_ = Approximate(c, -4)
+	assert.Equal(t, 9, knownMSD(c))
+	_ = Approximate(c, -10)
+	assert.False(t, c.tracker().msdValid)
+	assert.Equal(t, 9, knownMSD(c))
+}
+
+func BenchmarkPowPiE(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		// fresh constants, since Pi() and E() memoize their approximations
+		m1 := Multiply(FromInt(6), newIntegralArctan(FromInt(8)))
+		m2 := Multiply(FromInt(2), newIntegralArctan(FromInt(57)))
+		m3 := newIntegralArctan(FromInt(239))
+		pi := Multiply(FromInt(4), Add(m1, Add(m2, m3)))
+		e := newPrescaledExponential(FromInt(1))
+
+		_ = Approximate(Pow(pi, e), -1000)
+	}
+}
+
+func BenchmarkApproximateSmallInteger(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = Approximate(FromInt(5), -10)
+	}
+}
+
+func BenchmarkApproximateE(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = Approximate(newPrescaledExponential(One()), -5000)
+	}
+}
+
+func BenchmarkApproximatePi(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m1 := Multiply(FromInt(6), newIntegralArctan(FromInt(8)))
+		m2 := Multiply(FromInt(2), newIntegralArctan(FromInt(57)))
+		m3 := newIntegralArctan(FromInt(239))
+		_ = Approximate(Multiply(FromInt(4), Add(m1, Add(m2, m3))), -5000)
+	}
+}
+
+func BenchmarkApproximateCosine(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = Approximate(newPrescaledCosine(One()), -5000)
+	}
+}
+
+func BenchmarkApproximateLn(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = Approximate(newPrescaledNaturalLog(FromRat(1, 3)), -5000)
+	}
+}
+
+func TestScaleInto(t *testing.T) {
+	for _, v := range []int64{0, 1, -1, 2, -2, 3, -3, 5, -5, 1023, -1023, 1 << 40, -(1 << 40)} {
+		for n := -45; n <= 5; n++ {
+			i := big.NewInt(v)
+			// rounding of a multiplication by 2^n, with ties rounded up
+			expected := new(big.Int).Set(i)
+			if n >= 0 {
+				expected.Lsh(expected, uint(n))
+			} else {
+				expected.Lsh(expected, 1)
+				expected.Rsh(expected, uint(-n))
+				expected.Add(expected, big.NewInt(1))
+				expected.Rsh(expected, 1)
+			}
+
+			assert.Equal(t, expected.String(), scale(i, n).String(), "scale(%d, %d)", v, n)
+			assert.Equal(t, v, i.Int64(), "scale must not modify its argument")
+
+			dst := new(big.Int)
+			assert.Equal(t, expected.String(), scaleInto(dst, i, n).String(), "scaleInto(%d, %d)", v, n)
+			assert.Equal(t, expected.String(), scaleInto(i, i, n).String(), "aliased scaleInto(%d, %d)", v, n)
+		}
+	}
+	assert.Equal(t, int64(1), bigOne.Int64())
+}
+
+// countingReal counts how many times its approximation is computed.
+type countingReal struct {
+	precisionTracker
+	r     Real
+	delay time.Duration
+	count atomic.Int32
+}
+
+func (c *countingReal) approximate(p int) *big.Int {
+	c.count.Add(1)
+	time.Sleep(c.delay)
+	return Approximate(c.r, p)
+}
+
+func (c *countingReal) asConstruction() string {
+	return c.r.asConstruction()
+}
+
+func TestApproximate_Concurrent(t *testing.T) {
+	c := &countingReal{r: Pi(), delay: 50 * time.Millisecond}
+	expected := Approximate(Pi(), -2000)
+
+	start := make(chan struct{})
+	results := make([]*big.Int, 16)
+	wg := sync.WaitGroup{}
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			results[i] = Approximate(c, -2000)
+		}(i)
+	}
+
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), c.count.Load())
+	for _, result := range results {
+		assert.Equal(t, 0, expected.Cmp(result))
+	}
+}
+
+func TestApproximate_ConcurrentPanic(t *testing.T) {
+	c := &countingReal{r: Inverse(Zero()), delay: 50 * time.Millisecond}
+
+	start := make(chan struct{})
+	panics := atomic.Int32{}
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					panics.Add(1)
+				}
+			}()
+			<-start
+			_ = Approximate(c, -100)
+		}()
+	}
+
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, int32(8), panics.Load())
+	assert.Equal(t, "<undefined: division by zero>", Text(c, 10, 10))
+}
+
+func TestApproximate_ConcurrentShared(t *testing.T) {
+	// shared constants approximated at different precisions concurrently
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c := Add(Multiply(Pi(), E()), Sqrt2())
+			assert.Equal(t, "9.9539477850466621142652395937562725736046", Text(c, 40, 10))
+		}(i)
+	}
+	wg.Wait()
+}
+
+func BenchmarkApproximate_Concurrent(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		m1 := Multiply(FromInt(6), newIntegralArctan(FromInt(8)))
+		m2 := Multiply(FromInt(2), newIntegralArctan(FromInt(57)))
+		m3 := newIntegralArctan(FromInt(239))
+		pi := Multiply(FromInt(4), Add(m1, Add(m2, m3)))
+
+		wg := sync.WaitGroup{}
+		for j := 0; j < 8; j++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = Approximate(pi, -2000)
+			}()
+		}
+		wg.Wait()
+	}
+}
+
+func TestExp_Large(t *testing.T) {
+	e := Exp(FromInt(100000))
+	// e^100000 ≈ 2.80666336042612317931838581857174270853636627056588654538744e43429
+	text := Text(e, 0, 10)
+	assert.Len(t, text, 43430)
+	assert.Equal(t, "28066633604261231793183858185717427085363662705658", text[:50])
+
+	assert.Equal(t, "1970071114017046993888879352243323125316937985323845789952", Text(Exp(FromInt(1000)), 0, 10)[:58])
+	assertEqualAtPrecision(t, Exp(FromInt(-1000)), Inverse(Exp(FromInt(1000))), -100)
+}
+
+func TestCosine_Large(t *testing.T) {
+	// cos(1000) = 0.562379076290702991078249226605395968755811821738...
+	assert.Equal(t, "0.5623790762907029910782492266053959687558118217", Text(Cosine(FromInt(1000)), 46, 10))
+	assertEqualAtPrecision(t, Cosine(FromInt(1000)), Cosine(FromInt(-1000)), -100)
+	assertEqualAtPrecision(t, FromInt(-1), Cosine(Multiply(FromInt(1001), Pi())), -100)
+}
+
+type truncationTest struct {
+	name     string
+	series   func() Real
+	term     func(k int) *big.Rat
+	expected Real
+}
+
+// alternating returns r, negated when k is odd.
+func alternating(k int, r *big.Rat) *big.Rat {
+	if k%2 == 1 {
+		return r.Neg(r)
+	}
+	return r
+}
+
+var truncationTests = []truncationTest{
+	{"exp", func() Real { return newPrescaledExponential(One()) }, func(k int) *big.Rat {
+		// 1/k!
+		return new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).MulRange(1, int64(k)))
+	}, E()},
+	{"ln", func() Real { return newPrescaledNaturalLog(FromRat(1, 2)) }, func(k int) *big.Rat {
+		// ±(1/2)^(k+1) / (k+1)
+		return alternating(k, new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Lsh(big.NewInt(int64(k+1)), uint(k+1))))
+	}, Ln(FromRat(3, 2))},
+	{"arctan", func() Real { return newIntegralArctan(FromInt(5)) }, func(k int) *big.Rat {
+		// ±1 / ((2k+1) 5^(2k+1))
+		d := new(big.Int).Exp(big.NewInt(5), big.NewInt(int64(2*k+1)), nil)
+		return alternating(k, new(big.Rat).SetFrac(big.NewInt(1), d.Mul(d, big.NewInt(int64(2*k+1)))))
+	}, Divide(Add(Pi(), Multiply(FromInt(4), newIntegralArctan(FromInt(239)))), FromInt(16))},
+	{"cosine", func() Real { return newPrescaledCosine(One()) }, func(k int) *big.Rat {
+		// ±1/(2k)!
+		return alternating(k, new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).MulRange(1, int64(2*k))))
+	}, Sine(Subtract(Divide(Pi(), Two()), One()))},
+}
+
+func TestSeriesTruncation(t *testing.T) {
+	for _, test := range truncationTests {
+		t.Run(test.name, func(t *testing.T) {
+			// the expected values are built from independent, untruncated series
+			expected := Approximate(test.expected, -200)
+			within := func(appr *big.Int) bool {
+				return bigSub(appr, expected).CmpAbs(big.NewInt(2)) < 0
+			}
+
+			// the iteration bound is the fewest terms whose exact partial sum
+			// is within tolerance
+			bound := 0
+			for sum := new(big.Rat); !within(Approximate(FromRatExact(sum), -200)); bound++ {
+				sum.Add(sum, test.term(bound))
+			}
+			assert.Greater(t, bound, 2)
+
+			// maxIters counts the terms beyond the first, so that the series
+			// sums one term fewer than the bound
+			restore := setMaxIters(bound - 2)
+			truncated := Approximate(test.series(), -200)
+			restore()
+			assert.False(t, within(truncated), "truncated series should be outside tolerance")
+
+			full := Approximate(test.series(), -200)
+			assert.True(t, within(full), "full series should be within tolerance")
+		})
+	}
+}
+
+// lnRecursive is the recursive range reduction formerly used by Ln, kept as
+// a reference.
+func lnRecursive(c Real) Real {
+	rough := Approximate(c, -4)
+	if rough.Cmp(big.NewInt(8)) < 0 {
+		return Negate(lnRecursive(Inverse(c)))
+	}
+	if rough.Cmp(big.NewInt(24)) > 0 {
+		return ShiftLeft(lnRecursive(Sqrt(Sqrt(c))), 2)
+	}
+	return SimpleLn(c)
+}
+
+func TestLn_Large(t *testing.T) {
+	assertEqualAtPrecision(t, Multiply(FromInt(100), Ln(Ten())), Ln(Pow10(FromInt(100))), -100)
+	assertEqualAtPrecision(t, Multiply(FromInt(1000), Ln2()), Ln(ShiftLeft(One(), 1000)), -200)
+
+	for _, c := range []Real{FromFloat64(1e300), FromInt(25), FromFloat64(1.6), FromInt(1 << 40), FromRat(1, 10), Pi()} {
+		assertEqualAtPrecision(t, lnRecursive(c), Ln(c), -500)
+	}
+}
+
+func TestLnHalvings(t *testing.T) {
+	for _, c := range []Real{FromInt(2), FromInt(3), FromInt(100), FromFloat64(1e300), ShiftLeft(One(), 5000)} {
+		rough := Approximate(c, -4)
+		k := lnHalvings(rough)
+
+		r := c
+		for i := 0; i < k; i++ {
+			r = Sqrt(r)
+		}
+		assert.LessOrEqual(t, Approximate(r, -4).Cmp(big.NewInt(24)), 0, "estimate for %s fell short", AsConstruction(c))
+	}
+}
+
+func TestPolyEval(t *testing.T) {
+	// 1 + 2x + 3x² at x = 2
+	assertEqualAtPrecision(t, FromInt(17), PolyEval(FromIntSlice([]int{1, 2, 3}), FromInt(2)), -100)
+	// x² - x - 1 at φ
+	assertEqualAtPrecision(t, Zero(), PolyEval(FromIntSlice([]int{-1, -1, 1}), Phi()), -100)
+	assertEqualAtPrecision(t, Pi(), PolyEval([]Real{Pi()}, E()), -100)
+	assertEqualAtPrecision(t, Zero(), PolyEval(nil, E()), -100)
+}
+
+func BenchmarkPolyEval(b *testing.B) {
+	ints := make([]int, 200)
+	for i := range ints {
+		ints[i] = (i*7919)%1000 - 500
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = Approximate(PolyEval(FromIntSlice(ints), FromRat(1, 3)), -1000)
+	}
+}
+
+func TestApproximate_IntegerCache(t *testing.T) {
+	c := FromInt(5)
+	assert.Equal(t, "5120", Approximate(c, -10).String())
+
+	appr, prec, ok := c.tracker().Approximation()
+	assert.True(t, ok)
+	assert.Equal(t, -10, prec)
+	assert.Equal(t, "5120", appr.String())
+
+	// coarser approximations are served from the cache
+	assert.Equal(t, "160", Approximate(c, -5).String())
+	_, prec, _ = c.tracker().Approximation()
+	assert.Equal(t, -10, prec)
+
+	assert.Equal(t, "3", Approximate(c, 1).String())
+	assert.Equal(t, "-2", Approximate(FromInt(-5), 1).String())
+}
+
+func TestFromRatExact(t *testing.T) {
+	r := FromRatExact(big.NewRat(22, 7))
+	v, ok, err := Identify(r)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "22/7", v.String())
+	assert.True(t, IsRational(r))
+	assert.Equal(t, "Rat(22/7)", AsConstruction(r))
+
+	// the accessor returns a copy
+	v.SetInt64(0)
+	assert.Equal(t, "22/7", r.(*constructiveRational).Rat().String())
+
+	for _, br := range []*big.Rat{big.NewRat(22, 7), big.NewRat(-22, 7), big.NewRat(1, 3), big.NewRat(-5, 2), big.NewRat(0, 1), big.NewRat(1<<40, 3)} {
+		for _, p := range []int{-100, -10, -1, 0, 1, 3, 10} {
+			assert.Equal(t, Approximate(Divide(FromBigInt(br.Num()), FromBigInt(br.Denom())), p).String(), Approximate(FromRatExact(br), p).String(), "%s at %d", br, p)
+		}
+	}
+
+	assert.Equal(t, "3.14285714285714285714", Text(r, 20, 10))
+	assertEqualAtPrecision(t, FromRat(22, 7), r, -100)
+	assert.Nil(t, FromRatExact(nil))
+}
+
+func TestMSD(t *testing.T) {
+	n, ok := MSD(FromInt(8))
+	assert.True(t, ok)
+	assert.Contains(t, []int{3, 4}, n)
+
+	n, ok = MSD(Inverse(FromInt(8)))
+	assert.True(t, ok)
+	assert.Contains(t, []int{-3, -2}, n)
+
+	n, ok = MSD(Negate(ShiftLeft(One(), 200)))
+	assert.True(t, ok)
+	assert.Contains(t, []int{200, 201}, n)
+
+	n, ok = MSD(Pi())
+	assert.True(t, ok)
+	assert.Contains(t, []int{1, 2}, n)
+
+	_, ok = MSD(Zero())
+	assert.False(t, ok)
+	_, ok = MSD(ShiftRight(One(), 200))
+	assert.False(t, ok)
+}
+
+type prettyTest struct {
+	input    Real
+	expected string
+}
+
+var prettyTests = []prettyTest{
+	{Sqrt(Add(FromInt(1), FromInt(2))), "√(Integer(1) + Integer(2))"},
+	{newPrescaledExponential(Inverse(FromInt(2))), "e^(Inverse(Integer(2)))"},
+	{newPrescaledCosine(Inverse(FromInt(2))), "cos(Inverse(Integer(2)))"},
+	{newPrescaledNaturalLog(Inverse(FromInt(2))), "ln(Inverse(Integer(2)))"},
+	{Negate(FromInt(3)), "-(Integer(3))"},
+	{ShiftLeft(FromInt(3), 4), "(Integer(3) << 4)"},
+	{ShiftRight(FromInt(3), 4), "(Integer(3) >> 4)"},
+	{Abs(FromInt(-2)), "(Integer(-2) < 0 ? -(Integer(-2)) : Integer(-2))"},
+	{FromRatExact(big.NewRat(22, 7)), "Rational(22/7)"},
+	{Inverse(Zeta(3)), "Inverse(ζ(3))"},
+	{LambertW(One()), "W(Named(\"1\"))"},
+	{NthRoot(Add(FromInt(1), FromInt(2)), 3), "3√(Integer(1) + Integer(2))"},
+}
+
+func TestPretty(t *testing.T) {
+	for _, test := range prettyTests {
+		assert.Equal(t, test.expected, Pretty(test.input))
+	}
+
+	assert.Equal(t, "nil", Pretty(nil))
+}
+
+func TestPrettyShared(t *testing.T) {
+	assert.Equal(t, "let x0 = Named(\"π\")\nMultiply(x0, x0)", PrettyShared(Square(Pi())))
+
+	// without sharing, the output matches Pretty
+	c := Sqrt(Add(FromInt(1), FromInt(2)))
+	assert.Equal(t, Pretty(c), PrettyShared(c))
+
+	// bindings are listed before the bindings that depend on them
+	x := Add(FromInt(1), FromInt(2))
+	y := Multiply(x, x)
+	assert.Equal(t, "let x0 = Integer(1) + Integer(2)\nlet x1 = Multiply(x0, x0)\nx1 + Inverse(x1)", PrettyShared(Add(y, Inverse(y))))
+}
+
+type latexTest struct {
+	input    Real
+	expected string
+}
+
+var latexTests = []latexTest{
+	{Unwrap(Phi()), `\frac{1 + \sqrt{5}}{2}`},
+	{Phi(), `\varphi`},
+	{Multiply(FromInt(2), Pi()), `2 \cdot \pi`},
+	{Subtract(E(), FromInt(-3)), `\mathrm{e} - \left(-3\right)`},
+	{Multiply(Add(One(), Sqrt2()), Negate(Tau())), `\left(1 + \sqrt{2}\right) \cdot \left(-\tau\right)`},
+	{Inverse(FromInt(7)), `\frac{1}{7}`},
+	{FromRatExact(big.NewRat(-22, 7)), `-\frac{22}{7}`},
+	{ShiftLeft(FromInt(3), 4), `3 \cdot 2^{4}`},
+	{ShiftRight(Add(One(), Two()), 4), `\frac{1 + 2}{2^{4}}`},
+	{newPrescaledExponential(FromRat(1, 2)), `e^{\frac{1}{2}}`},
+	{newPrescaledCosine(Named("x", FromRat(1, 2))), `\cos\left(\mathrm{x}\right)`},
+	{newIntegralArctan(FromInt(239)), `\arctan\left(\frac{1}{239}\right)`},
+	{Zeta(5), `\zeta(5)`},
+	{NthRoot(Add(FromInt(1), FromInt(2)), 3), `\sqrt[3]{1 + 2}`},
+}
+
+func TestAsLaTeX(t *testing.T) {
+	for _, test := range latexTests {
+		assert.Equal(t, test.expected, AsLaTeX(test.input))
+	}
+}
+
+func TestAsConstructionDAG(t *testing.T) {
+	// without sharing, the output matches AsConstruction
+	for _, test := range asConstructionTests {
+		assert.Equal(t, AsConstruction(test.input), AsConstructionDAG(test.input))
+	}
+
+	x := Add(FromInt(1), FromInt(2))
+	assert.Equal(t, "let x0 = Add(Int(1), Int(2)); Multiply(x0, x0)", AsConstructionDAG(Square(x)))
+
+	for i := 0; i < 10; i++ {
+		x = Square(x)
+	}
+	assert.Greater(t, len(AsConstruction(x)), 20000)
+	assert.Less(t, len(AsConstructionDAG(x)), 500)
+	assert.Contains(t, AsConstructionDAG(x), "let x9 = Multiply(x8, x8); Multiply(x9, x9)")
+}
+
+func TestUnwrap(t *testing.T) {
+	assert.Equal(t, Approximate(One(), -50), Approximate(Unwrap(One()), -50))
+	assert.Equal(t, "Int(1)", AsConstruction(Unwrap(One())))
+	assert.Equal(t, "Int(1)", AsConstruction(Unwrap(Named("a", Named("b", One())))))
+
+	pi := Pi()
+	assert.Same(t, Unwrap(pi), Unwrap(Named("τ/2", pi)))
+
+	x := Sqrt(FromInt(2))
+	assert.Same(t, x, Unwrap(x))
+
+	r, ok, err := Identify(Named("two", FromInt(2)))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, big.NewRat(2, 1), r)
+}
+
+func TestNamed(t *testing.T) {
+	half := Named("myhalf", FromRat(1, 2))
+	assert.Equal(t, `Named("myhalf", Multiply(Int(1), Inverse(Int(2))))`, AsConstruction(half))
+	assert.Equal(t, `Named("myhalf")`, Pretty(half))
+
+	name, ok := ConstructiveName(half)
+	assert.True(t, ok)
+	assert.Equal(t, "myhalf", name)
+
+	_, ok = ConstructiveName(FromRat(1, 2))
+	assert.False(t, ok)
+
+	assertEqualAtPrecision(t, FromRat(1, 2), half, -100)
+}
+
+func TestNamedNodes(t *testing.T) {
+	nodes := NamedNodes(Add(Pi(), Multiply(E(), Phi())))
+	assert.Len(t, nodes, 3)
+	assert.Same(t, Pi(), nodes["π"])
+	assert.Same(t, E(), nodes["e"])
+	assert.Same(t, Phi(), nodes["φ"])
+
+	// names nested inside named nodes are found too
+	nodes = NamedNodes(Named("x", Square(Two())))
+	assert.Len(t, nodes, 2)
+	assert.Contains(t, nodes, "x")
+	assert.Contains(t, nodes, "2")
+
+	assert.Empty(t, NamedNodes(FromInt(3)))
+}
+
+func TestInverse_Tiny(t *testing.T) {
+	// the operand is far below the precision of the approximation
+	assertEqualAtPrecision(t, ShiftLeft(One(), 200), Inverse(ShiftRight(One(), 200)), -4)
+	assertEqualAtPrecision(t, Exp(FromInt(100)), Inverse(Exp(FromInt(-100))), -50)
+	assertEqualAtPrecision(t, FromInt(-100), Ln(Exp(FromInt(-100))), -100)
+	assert.Equal(t, "<undefined: division by zero>", Text(Inverse(Zero()), 4, 10))
+
+	// below the search bound, the operand is treated as zero
+	assert.Equal(t, "<undefined: division by zero>", Text(Inverse(ShiftRight(One(), 5000)), 4, 10))
+}
+
+func TestInverseErr(t *testing.T) {
+	_, err := InverseErr(Subtract(FromInt(1), FromInt(1)))
+	assert.ErrorIs(t, err, ErrDivisionByZero)
+
+	_, err = InverseErr(Zero())
+	assert.ErrorIs(t, err, ErrDivisionByZero)
+
+	_, err = InverseErr(ShiftRight(One(), 200))
+	assert.ErrorIs(t, err, ErrDivisionByZero)
+
+	r, err := InverseErr(FromInt(-4))
+	assert.NoError(t, err)
+	assertEqualAtPrecision(t, FromRat(-1, 4), r, -100)
+
+	r, err = InverseErr(Pi())
+	assert.NoError(t, err)
+	assertEqualAtPrecision(t, Inverse(Pi()), r, -100)
+}
+
+func TestDivideErr(t *testing.T) {
+	_, err := DivideErr(FromInt(1), Zero())
+	assert.ErrorIs(t, err, ErrDivisionByZero)
+
+	_, err = DivideErr(Pi(), Subtract(E(), E()))
+	assert.ErrorIs(t, err, ErrDivisionByZero)
+
+	r, err := DivideErr(FromInt(6), FromInt(2))
+	assert.NoError(t, err)
+	assertEqualAtPrecision(t, FromInt(3), r, -100)
+
+	r, err = DivideErr(FromInt(6), FromInt(-4))
+	assert.NoError(t, err)
+	assertEqualAtPrecision(t, FromRat(-3, 2), r, -100)
+}
+
+func TestApproximateAll(t *testing.T) {
+	assert.Equal(t, []*big.Int{big.NewInt(8), big.NewInt(16)}, ApproximateAll([]Real{FromInt(1), FromInt(2)}, -3))
+	assert.Empty(t, ApproximateAll(nil, -3))
+
+	cs := []Real{Pi(), E(), Sqrt(FromInt(2)), Ln(FromInt(3)), Cosine(FromInt(1)), FromRat(1, 3)}
+	expected := make([]*big.Int, len(cs))
+	for i, c := range cs {
+		expected[i] = Approximate(c, -200)
+	}
+
+	for _, workers := range []int{0, 1, 2, 4, 16} {
+		assert.Equal(t, expected, ApproximateAllParallel(cs, -200, workers), "workers=%d", workers)
+	}
+}
+
+func TestApproximateAllParallel_Panic(t *testing.T) {
+	cs := []Real{Pi(), Inverse(Zero()), E(), Inverse(Subtract(Pi(), Pi())), Sqrt2()}
+	for _, workers := range []int{1, 2, 4, 16} {
+		assert.PanicsWithValue(t, "division by zero", func() {
+			ApproximateAllParallel(cs, -100, workers)
+		}, "workers=%d", workers)
+	}
+}
+
+func TestTabulate(t *testing.T) {
+	sines := Tabulate(Sine, Zero(), Divide(Pi(), FromInt(6)), 4)
+	expected := []Real{Zero(), FromRat(1, 2), Divide(Sqrt(FromInt(3)), FromInt(2)), One()}
+	assert.Len(t, sines, len(expected))
+	for i := range expected {
+		assertEqualAtPrecision(t, expected[i], sines[i], -100)
+	}
+
+	// the argument at each point is built from the previous one
+	var args []Real
+	Tabulate(func(x Real) Real {
+		args = append(args, x)
+		return x
+	}, FromInt(1), FromInt(2), 3)
+	assert.Equal(t, "Int(1)", AsConstruction(args[0]))
+	assert.Same(t, args[1], args[2].(*constructiveAddition).a)
+	assertEqualAtPrecision(t, FromInt(5), args[2], -100)
+
+	assert.Nil(t, Tabulate(Sine, Zero(), One(), 0))
+}
+
+func TestLerp(t *testing.T) {
+	assertEqualAtPrecision(t, FromRat(5, 2), Lerp(FromInt(0), FromInt(10), FromRat(1, 4)), -100)
+	assertEqualAtPrecision(t, FromInt(-5), Lerp(FromInt(5), FromInt(15), FromInt(-1)), -100)
+	assertEqualAtPrecision(t, Divide(Add(Pi(), E()), FromInt(2)), Lerp(Pi(), E(), FromRat(1, 2)), -100)
+
+	a, b := Pi(), Sqrt(FromInt(2))
+	assert.Same(t, a, Lerp(a, b, Zero()))
+	assert.Same(t, b, Lerp(a, b, One()))
+}
+
+func TestProduct(t *testing.T) {
+	assertEqualAtPrecision(t, FromInt(120), Product(FromIntSlice([]int{1, 2, 3, 4, 5})...), -100)
+	assertEqualAtPrecision(t, Multiply(Pi(), E()), Product(Pi(), E()), -100)
+	assert.Same(t, Pi(), Product(Pi()))
+	assertEqualAtPrecision(t, One(), Product(), -100)
+}
+
+func TestNthRoot(t *testing.T) {
+	assertEqualAtPrecision(t, FromInt(2), NthRoot(FromInt(8), 3), -100)
+	assertEqualAtPrecision(t, FromInt(-2), NthRoot(FromInt(-8), 3), -100)
+	assertEqualAtPrecision(t, FromInt(3), NthRoot(FromInt(81), 4), -100)
+	assertEqualAtPrecision(t, Sqrt(FromInt(2)), NthRoot(FromInt(2), 2), -100)
+	assert.Same(t, Pi(), NthRoot(Pi(), 1))
+
+	assert.Nil(t, NthRoot(FromInt(-16), 4))
+	assert.Nil(t, NthRoot(FromInt(-8), 2))
+	assert.Nil(t, NthRoot(FromRat(-1, 1<<40), 2))
+	assertEqualAtPrecision(t, Zero(), NthRoot(Zero(), 3), -100)
+	assertEqualAtPrecision(t, Zero(), NthRoot(Zero(), 2), -100)
+	assertEqualAtPrecision(t, Zero(), NthRoot(Subtract(Pi(), Pi()), 5), -100)
+	assert.Nil(t, NthRoot(FromInt(8), 0))
+
+	assertEqualAtPrecision(t, Two(), Multiply(Square(NthRoot(Two(), 3)), NthRoot(Two(), 3)), -200)
+	assertEqualAtPrecision(t, FromInt64(1<<20), NthRoot(FromInt64(1<<60), 3), -100)
+	assertEqualAtPrecision(t, FromRat(1, 1<<20), NthRoot(FromRat(1, 1<<60), 3), -100)
+	assertEqualAtPrecision(t, NthRoot(FromInt(10), 7), Exp(Divide(Ln(FromInt(10)), FromInt(7))), -200)
+
+	// an operand below 2^-4096 is not zero, and its root is built lazily
+	assertEqualAtPrecision(t, ShiftRight(One(), 1500), NthRoot(ShiftRight(One(), 4500), 3), -1600)
+	assertEqualAtPrecision(t, Negate(ShiftRight(One(), 1500)), NthRoot(Negate(ShiftRight(One(), 4500)), 3), -1600)
+	assertEqualAtPrecision(t, ShiftRight(One(), 1500), NthRoot(ShiftRight(Multiply(Exp(FromInt(-1)), E()), 4500), 3), -1600)
+}
+
+func TestGeoMean(t *testing.T) {
+	assertEqualAtPrecision(t, FromInt(4), GeoMean(FromIntSlice([]int{1, 4, 16})), -100)
+	assertEqualAtPrecision(t, Sqrt(Multiply(Pi(), E())), GeoMean([]Real{Pi(), E()}), -100)
+	assert.Same(t, Pi(), GeoMean([]Real{Pi()}))
+
+	assert.Nil(t, GeoMean(nil))
+	assert.Nil(t, GeoMean(FromIntSlice([]int{1, 0, 4})))
+	assert.Nil(t, GeoMean(FromIntSlice([]int{1, -2, 4})))
+}
+
+func TestHarmonicMean(t *testing.T) {
+	assertEqualAtPrecision(t, FromRat(12, 7), HarmonicMean(FromIntSlice([]int{1, 2, 4})), -100)
+	assertEqualAtPrecision(t, FromInt(3), HarmonicMean([]Real{FromInt(3)}), -100)
+	assertEqualAtPrecision(t, FromRat(-4, 3), HarmonicMean(FromIntSlice([]int{-1, -2})), -100)
+
+	assert.Nil(t, HarmonicMean(nil))
+	assert.Nil(t, HarmonicMean(FromIntSlice([]int{1, 0, 4})))
+}
+
+func TestPow10Int(t *testing.T) {
+	expected := map[int]string{
+		-3: "1/1000",
+		-1: "1/10",
+		0:  "1",
+		1:  "10",
+		20: "100000000000000000000",
+	}
+	for n, str := range expected {
+		r, ok, err := Identify(Pow10Int(n))
+		assert.NoError(t, err)
+		assert.True(t, ok, "10^%d", n)
+		assert.Equal(t, str, r.RatString(), "10^%d", n)
+	}
+
+	thousandth, _, _ := Identify(Divide(FromInt(1), FromInt(1000)))
+	r, _, _ := Identify(Pow10Int(-3))
+	assert.Equal(t, 0, thousandth.Cmp(r))
+	assert.True(t, IsRational(Pow10Int(-3)))
+
+	assertEqualAtPrecision(t, Pow10(FromInt(5)), Pow10Int(5), -100)
+}
+
+func TestPow2Int(t *testing.T) {
+	assertEqualAtPrecision(t, FromRat(1, 16), Pow2Int(-4), -100)
+	assertEqualAtPrecision(t, FromInt(1024), Pow2Int(10), -100)
+	assertEqualAtPrecision(t, One(), Pow2Int(0), -100)
+
+	r, ok, err := Identify(Pow2Int(-4))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "1/16", r.RatString())
+	assert.True(t, IsRational(Pow2Int(10)))
+}
+
+type binomialTest struct {
+	n, k     int
+	expected int64
+}
+
+var binomialTests = []binomialTest{
+	{10, 3, 120},
+	{5, 0, 1},
+	{5, 5, 1},
+	{0, 0, 1},
+	{52, 5, 2598960},
+	{60, 30, 118264581564861424},
+}
+
+func TestBinomial(t *testing.T) {
+	for _, test := range binomialTests {
+		r, ok, err := Identify(Binomial(test.n, test.k))
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, big.NewRat(test.expected, 1), r, "C(%d, %d)", test.n, test.k)
+	}
+
+	// C(100, 50) does not fit in 64 bits
+	r, _, _ := Identify(Binomial(100, 50))
+	assert.Equal(t, "100891344545564193334812497256", r.RatString())
+
+	assert.Nil(t, Binomial(5, 6))
+	assert.Nil(t, Binomial(5, -1))
+	assert.Nil(t, Binomial(-5, 2))
+}
+
+// expCoeff is the coefficient 1/n! of the Taylor series of e^x.
+func expCoeff(n int) *big.Rat {
+	return new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).MulRange(1, int64(n)))
+}
+
+// expTail bounds Σ 1/k! for k >= n by 2/n!, or by 3 for n = 0.
+func expTail(n int) *big.Rat {
+	if n == 0 {
+		return big.NewRat(3, 1)
+	}
+	return new(big.Rat).Mul(big.NewRat(2, 1), expCoeff(n))
+}
+
+func TestSeries(t *testing.T) {
+	assertEqualAtPrecision(t, E(), Square(Series(expCoeff, FromRat(1, 2))), -100)
+	assertEqualAtPrecision(t, Exp(FromRat(1, 3)), Series(expCoeff, FromRat(1, 3)), -200)
+	assertEqualAtPrecision(t, Exp(FromRat(-1, 2)), Series(expCoeff, FromRat(-1, 2)), -100)
+	assertEqualAtPrecision(t, One(), Series(expCoeff, Zero()), -100)
+
+	// sin(x) = Σ (-1)^k x^(2k+1) / (2k+1)!, whose even coefficients are zero
+	sinCoeff := func(n int) *big.Rat {
+		if n%2 == 0 {
+			return new(big.Rat)
+		}
+		r := expCoeff(n)
+		if n%4 == 3 {
+			r.Neg(r)
+		}
+		return r
+	}
+	assertEqualAtPrecision(t, Sine(FromRat(1, 2)), Series(sinCoeff, FromRat(1, 2)), -100)
+
+	// 1/(1-x) = Σ x^n converges slowly, but still converges for |x| <= 1/2
+	geometric := func(int) *big.Rat { return big.NewRat(1, 1) }
+	assertEqualAtPrecision(t, FromInt(2), Series(geometric, FromRat(1, 2)), -100)
+	assertEqualAtPrecision(t, FromRat(4, 5), Series(geometric, FromRat(-1, 4)), -100)
+
+	// Σ x^(3k) = 1/(1-x³), whose coefficients are mostly zero
+	sparse := func(n int) *big.Rat { return big.NewRat(int64(1-min(n%3, 1)), 1) }
+	assertEqualAtPrecision(t, FromRat(8, 7), Series(sparse, FromRat(1, 2)), -100)
+	assertEqualAtPrecision(t, FromRat(8, 7), Series(sparse, FromRat(1, 2)), -4)
+
+	assert.Nil(t, Series(expCoeff, One()))
+	assert.Nil(t, Series(geometric, FromRat(-3, 4)))
+
+	for _, p := range []int{4, 0, -1, -4, -10} {
+		assertEqualAtPrecision(t, FromRat(8, 7), Series(sparse, FromRat(1, 2)), p)
+		assertEqualAtPrecision(t, E(), SeriesWithTail(expCoeff, One(), expTail), p)
+	}
+}
+
+func TestSeriesWithTail(t *testing.T) {
+	assertEqualAtPrecision(t, E(), SeriesWithTail(expCoeff, One(), expTail), -100)
+	assertEqualAtPrecision(t, Inverse(E()), SeriesWithTail(expCoeff, FromInt(-1), expTail), -100)
+	assertEqualAtPrecision(t, Exp(FromRat(3, 4)), SeriesWithTail(expCoeff, FromRat(3, 4), expTail), -200)
+}
+
+func TestSumSeries(t *testing.T) {
+	// ln(1+x) = Σ (-1)^(n+1) x^n / n, for n >= 1
+	lnCoeff := func(n int) *big.Rat {
+		if n == 0 {
+			return new(big.Rat)
+		}
+		r := big.NewRat(1, int64(n))
+		if n%2 == 0 {
+			r.Neg(r)
+		}
+		return r
+	}
+	// cos(x) = Σ (-1)^k x^(2k) / (2k)!
+	cosCoeff := func(n int) *big.Rat {
+		if n%2 == 1 {
+			return new(big.Rat)
+		}
+		r := expCoeff(n)
+		if n%4 == 2 {
+			r.Neg(r)
+		}
+		return r
+	}
+	// arctan(x) = Σ (-1)^k x^(2k+1) / (2k+1)
+	arctanCoeff := func(n int) *big.Rat {
+		if n%2 == 0 {
+			return new(big.Rat)
+		}
+		r := big.NewRat(1, int64(n))
+		if n%4 == 3 {
+			r.Neg(r)
+		}
+		return r
+	}
+
+	for _, x := range []Real{FromRat(1, 3), FromRat(-1, 5), ShiftRight(Pi(), 3)} {
+		assertEqualAtPrecision(t, Series(expCoeff, x), newPrescaledExponential(x), -100)
+		assertEqualAtPrecision(t, Series(lnCoeff, x), newPrescaledNaturalLog(x), -100)
+		assertEqualAtPrecision(t, Series(cosCoeff, x), newPrescaledCosine(x), -100)
+	}
+	for _, a := range []int{2, 8, 57, 239} {
+		assertEqualAtPrecision(t, Series(arctanCoeff, FromRat(1, a)), newIntegralArctan(FromInt(a)), -100)
+	}
+}
+
+func TestPrescaledCosine_CoarsePrecision(t *testing.T) {
+	// each approximation is made on a fresh node, so that it is not answered
+	// from the cache of a finer one, and compared against a reference that is
+	// 60 bits finer; the error must stay within one unit
+	one := bigLsh(big.NewInt(1), 60)
+	for k := -16; k <= 16; k++ {
+		x := Add(FromRat(k, 16), ShiftRight(Pi(), 12))
+		for _, p := range []int{-1, -2, -4, -6, -8, -12} {
+			appr := Approximate(newPrescaledCosine(x), p)
+			ref := Approximate(newPrescaledCosine(x), p-60)
+			diff := bigSub(bigLsh(appr, 60), ref)
+			assert.Less(t, diff.CmpAbs(one), 0, "cos(%d/16 + π/4096) at %d", k, p)
+		}
+	}
+}
+
+func TestUlp(t *testing.T) {
+	assertEqualAtPrecision(t, FromRat(1, 1024), Ulp(Pi(), -10), -100)
+	assertEqualAtPrecision(t, FromInt(8), Ulp(E(), 3), -100)
+
+	// the approximation, scaled back, is within one ulp of the value
+	for _, p := range []int{-1, -10, -50} {
+		appr := ShiftLeft(FromBigInt(Approximate(Pi(), p)), p)
+		assert.True(t, WithinAbs(Pi(), appr, Ulp(Pi(), p)), "at %d", p)
+	}
+}
+
+type float32Test struct {
+	input    Real
+	expected float32
+	ok       bool
+}
+
+var float32Tests = []float32Test{
+	{Pi(), float32(math.Pi), true},
+	{Negate(E()), -float32(math.E), true},
+	{Sqrt2(), float32(math.Sqrt2), true},
+	{Ln2(), float32(math.Ln2), true},
+	{FromRat(1, 3), float32(1) / 3, true},
+	{FromFloat32(0.1), 0.1, true},
+	{Zero(), 0, true},
+	{Subtract(Pi(), Pi()), 0, true},
+	{ShiftRight(Pi(), 140), float32(math.Pi / (1 << 70) / (1 << 70)), true},
+	{ShiftLeft(Pi(), 100), float32(math.Pi * (1 << 50) * (1 << 50)), true},
+	{ShiftLeft(Pi(), 200), float32(math.Inf(1)), false},
+	{Negate(ShiftLeft(One(), 200)), float32(math.Inf(-1)), false},
+}
+
+func TestFloat32(t *testing.T) {
+	for _, test := range float32Tests {
+		f, ok := Float32(test.input)
+		assert.Equal(t, test.expected, f, AsConstruction(test.input))
+		assert.Equal(t, test.ok, ok, AsConstruction(test.input))
+	}
+}
+
+func TestTextBits(t *testing.T) {
+	assert.Equal(t, "0.1000", TextBits(FromRat(1, 2), 4))
+	assert.Equal(t, "0b0.1000", TextBitsPrefixed(FromRat(1, 2), 4))
+	assert.Equal(t, "-0b1.1000", TextBitsPrefixed(FromRat(-3, 2), 4))
+	assert.Equal(t, "101", TextBits(FromInt(5), 0))
+	assert.Equal(t, "11.00100100", TextBits(Pi(), 8))
+	assert.Equal(t, Text(Inverse(FromInt(9)), 20, 2), TextBits(Inverse(FromInt(9)), 20))
+}
+
+func TestTextContext(t *testing.T) {
+	ctx := context.Background()
+
+	text, err := TextContext(ctx, Pi(), 20, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, Text(Pi(), 20, 10), text)
+
+	text, err = TextContext(ctx, FromRat(-1, 4), 4, 16)
+	assert.NoError(t, err)
+	assert.Equal(t, "-0.4000", text)
+
+	_, err = TextContext(WithPrecisionLimit(ctx, 100), Pi(), 100, 10)
+	assert.ErrorIs(t, err, PrecisionOverflow)
+
+	_, err = TextContext(ctx, Inverse(Zero()), 10, 10)
+	assert.Error(t, err)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = TextContext(canceled, Pi(), 10, 10)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	timeout, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = TextContext(timeout, Pi(), 100000, 10)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestCachedPrecision(t *testing.T) {
+	Approximate(Pi(), -100)
+	p, ok := CachedPrecision(Pi())
+	assert.True(t, ok)
+	assert.LessOrEqual(t, p, -100)
+
+	c := Sqrt(FromInt(3))
+	_, ok = CachedPrecision(c)
+	assert.False(t, ok)
+
+	Approximate(c, -50)
+	p, ok = CachedPrecision(c)
+	assert.True(t, ok)
+	assert.Equal(t, -50, p)
+
+	// a coarser approximation does not replace the finer one
+	Approximate(c, -20)
+	p, _ = CachedPrecision(c)
+	assert.Equal(t, -50, p)
+
+	// the operand of a shared node is approximated once, at the precision
+	// needed by the first use
+	x := Sqrt(FromInt(5))
+	Approximate(Add(x, x), -50)
+	xp, ok := CachedPrecision(x)
+	assert.True(t, ok)
+	Approximate(Multiply(FromInt(1), x), -40)
+	p, _ = CachedPrecision(x)
+	assert.Equal(t, xp, p)
+}
+
+func TestSumSlice(t *testing.T) {
+	assertEqualAtPrecision(t, FromInt(5050), SumSlice(FromIntSlice(makeRange(1, 100))), -100)
+	assertEqualAtPrecision(t, Zero(), SumSlice(nil), -100)
+}
+
+func TestCumSum(t *testing.T) {
+	sums := CumSum(FromIntSlice([]int{1, 2, 3}))
+	expected := FromIntSlice([]int{1, 3, 6})
+	assert.Len(t, sums, len(expected))
+	for i := range expected {
+		assertEqualAtPrecision(t, expected[i], sums[i], -100)
+	}
+
+	assert.Empty(t, CumSum(nil))
+}
+
+// makeRange returns the integers from lo to hi, inclusive.
+func makeRange(lo, hi int) []int {
+	out := make([]int, 0, hi-lo+1)
+	for i := lo; i <= hi; i++ {
+		out = append(out, i)
+	}
+	return out
+}
+
+func TestFactorial(t *testing.T) {
+	for n, expected := range []int64{1, 1, 2, 6, 24, 120} {
+		assertEqualAtPrecision(t, FromInt64(expected), Factorial(n), -100)
+	}
+
+	r, _, _ := Identify(Factorial(25))
+	assert.Equal(t, "15511210043330985984000000", r.RatString())
+	assert.Nil(t, Factorial(-1))
+}
+
+func TestProductSlice(t *testing.T) {
+	ints := make([]int64, 20)
+	for i := range ints {
+		ints[i] = int64(i + 1)
+	}
+
+	// 20! overflows a float64 mantissa, but not the big.Int-backed product
+	product, ok, err := Identify(ProductSlice(FromInt64Slice(ints)))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	factorial, _, _ := Identify(Factorial(20))
+	assert.Equal(t, 0, factorial.Cmp(product))
+	assert.Equal(t, "2432902008176640000", product.RatString())
+
+	assertEqualAtPrecision(t, Multiply(Square(Pi()), E()), ProductSlice([]Real{Pi(), E(), Pi()}), -100)
+	assertEqualAtPrecision(t, One(), ProductSlice(nil), -100)
+}
+
+func TestParallelSum(t *testing.T) {
+	assertEqualAtPrecision(t, One(), ParallelSum(FromIntSlice([]int{2, 2})), -100)
+	assertEqualAtPrecision(t, One(), ParallelSum(FromIntSlice([]int{6, 3, 2})), -100)
+	assertEqualAtPrecision(t, FromRat(20, 9), ParallelSum(FromIntSlice([]int{4, 5})), -100)
+	assertEqualAtPrecision(t, Pi(), ParallelSum([]Real{Pi()}), -100)
+
+	assert.Nil(t, ParallelSum(nil))
+	assert.Nil(t, ParallelSum(FromIntSlice([]int{1, 0})))
+}
+
+func TestGradians(t *testing.T) {
+	assertEqualAtPrecision(t, Pi(), GradiansToRadians(FromInt(200)), -100)
+	assertEqualAtPrecision(t, Multiply(Pi(), Two()), GradiansToRadians(FromInt(400)), -100)
+	assertEqualAtPrecision(t, FromInt(100), RadiansToGradians(Divide(Pi(), Two())), -100)
+	assertEqualAtPrecision(t, DegreesToRadians(FromInt(90)), GradiansToRadians(FromInt(100)), -100)
+	assertEqualAtPrecision(t, FromRat(-1, 3), RadiansToGradians(GradiansToRadians(FromRat(-1, 3))), -100)
+}
+
+func TestTurns(t *testing.T) {
+	assertEqualAtPrecision(t, Multiply(Pi(), Two()), Tau(), -100)
+	assertEqualAtPrecision(t, Divide(Pi(), Two()), TurnsToRadians(FromRat(1, 4)), -100)
+	assertEqualAtPrecision(t, Tau(), TurnsToRadians(One()), -100)
+	assertEqualAtPrecision(t, FromRat(1, 2), RadiansToTurns(Pi()), -100)
+	assertEqualAtPrecision(t, FromInt(-3), RadiansToTurns(TurnsToRadians(FromInt(-3))), -100)
+}
+
+func TestArgMax(t *testing.T) {
+	i, err := ArgMax([]Real{E(), Pi(), FromInt(3)})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, i)
+
+	i, err = ArgMin([]Real{E(), Pi(), FromInt(3)})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, i)
+
+	// a tie that is later beaten does not matter
+	i, err = ArgMax([]Real{FromInt(1), FromInt(1), FromInt(2)})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, i)
+
+	_, err = ArgMax([]Real{FromInt(2), Sqrt(FromInt(2)), Square(Sqrt(FromInt(2)))})
+	assert.ErrorIs(t, err, ErrIndistinguishable)
+	_, err = ArgMin([]Real{FromInt(1), Subtract(FromInt(2), One())})
+	assert.ErrorIs(t, err, ErrIndistinguishable)
+
+	// the last element ties with the second, but not with the first, which
+	// was the largest when the second was compared
+	_, err = ArgMax([]Real{Zero(), ShiftRight(One(), 101), Add(ShiftRight(One(), 100), ShiftRight(One(), 103))})
+	assert.ErrorIs(t, err, ErrIndistinguishable)
+
+	_, err = ArgMax(nil)
+	assert.ErrorIs(t, err, ErrEmpty)
+	_, err = ArgMin(nil)
+	assert.ErrorIs(t, err, ErrEmpty)
+}
+
+func TestStatsAccumulator(t *testing.T) {
+	s := &StatsAccumulator{}
+	assert.Equal(t, 0, s.Count())
+	assertEqualAtPrecision(t, Zero(), s.Sum(), -100)
+	assert.Nil(t, s.Mean())
+
+	for i := 1; i <= 10; i++ {
+		s.Add(FromInt(i))
+	}
+	assert.Equal(t, 10, s.Count())
+	assertEqualAtPrecision(t, FromInt(55), s.Sum(), -100)
+	assertEqualAtPrecision(t, FromRat(11, 2), s.Mean(), -100)
+
+	// 10 = 0b1010, so two partial sums remain
+	assert.Nil(t, s.partials[0])
+	assert.NotNil(t, s.partials[1])
+	assert.Nil(t, s.partials[2])
+	assert.NotNil(t, s.partials[3])
+
+	s.Add(Pi())
+	assertEqualAtPrecision(t, Add(FromInt(55), Pi()), s.Sum(), -100)
+}
+
+func TestVariance(t *testing.T) {
+	data := FromIntSlice([]int{2, 4, 4, 4, 5, 5, 7, 9})
+	assertEqualAtPrecision(t, FromInt(4), Variance(data), -100)
+	assertEqualAtPrecision(t, FromInt(2), StdDev(data), -100)
+
+	assertEqualAtPrecision(t, FromRat(2, 3), Variance(FromIntSlice([]int{1, 2, 3})), -100)
+	assertEqualAtPrecision(t, Zero(), Variance([]Real{Pi(), Pi()}), -100)
+	assertEqualAtPrecision(t, Zero(), StdDev([]Real{Pi()}), -100)
+
+	assert.Nil(t, Variance(nil))
+	assert.Nil(t, StdDev(nil))
+}
+
+func TestPiWith(t *testing.T) {
+	for _, algo := range []PiAlgorithm{MachinLike, Chudnovsky, GaussLegendre} {
+		assertEqualAtPrecision(t, Pi(), PiWith(algo), -1000)
+		assertEqualAtPrecision(t, Pi(), PiWith(algo), 3)
+	}
+
+	assert.Same(t, Pi(), PiWith(MachinLike))
+	assert.Nil(t, PiWith(PiAlgorithm(-1)))
+}
+
+func TestFibonacci(t *testing.T) {
+	fibs := []int64{0, 1, 1, 2, 3, 5, 8, 13, 21, 34, 55}
+	lucas := []int64{2, 1, 3, 4, 7, 11, 18, 29, 47, 76, 123}
+	for n := range fibs {
+		assertEqualAtPrecision(t, FromInt64(fibs[n]), Fibonacci(n), -100)
+		assertEqualAtPrecision(t, FromInt64(lucas[n]), Lucas(n), -100)
+	}
+
+	assertEqualAtPrecision(t, FromInt(6765), Fibonacci(20), -100)
+	assertEqualAtPrecision(t, Phi(), Divide(Fibonacci(30), Fibonacci(29)), -8)
+
+	r, _, _ := Identify(Fibonacci(100))
+	assert.Equal(t, "354224848179261915075", r.RatString())
+
+	assertEqualAtPrecision(t, FromInt(-8), Fibonacci(-6), -100)
+	assertEqualAtPrecision(t, FromInt(13), Fibonacci(-7), -100)
+	assertEqualAtPrecision(t, FromInt(18), Lucas(-6), -100)
+	assertEqualAtPrecision(t, FromInt(-29), Lucas(-7), -100)
+}
+
+func TestFibonacciBinet(t *testing.T) {
+	assertEqualAtPrecision(t, FromInt(55), FibonacciBinet(10), -50)
+	for _, n := range []int{-7, -6, -1, 0, 1, 2, 20, 64} {
+		assertEqualAtPrecision(t, Fibonacci(n), FibonacciBinet(n), -100)
+	}
+}
+
+type floorTest struct {
+	c        Real
+	expected int
+}
+
+var floorTests = []floorTest{
+	{FromInt(3), 3},
+	{FromInt(-3), -3},
+	{FromRat(7, 2), 3},
+	{FromRat(-7, 2), -4},
+	{Pi(), 3},
+	{Negate(Pi()), -4},
+	{ShiftRight(One(), 50), 0},
+	{Negate(ShiftRight(One(), 50)), -1},
+	{Square(Sqrt2()), 2},
+}
+
+func TestFloor(t *testing.T) {
+	for _, test := range floorTests {
+		assertEqualAtPrecision(t, FromInt(test.expected), Floor(test.c), -100)
+	}
+}
+
+func TestReduceMod2Pi(t *testing.T) {
+	halfPi := Divide(Pi(), Two())
+	assertEqualAtPrecision(t, halfPi, ReduceMod2Pi(Add(Tau(), halfPi)), -100)
+	assertEqualAtPrecision(t, halfPi, ReduceMod2Pi(halfPi), -100)
+	assertEqualAtPrecision(t, Multiply(FromInt(3), halfPi), ReduceMod2Pi(Negate(halfPi)), -100)
+	assertEqualAtPrecision(t, FromInt(1), ReduceMod2Pi(Add(Multiply(FromInt(-5), Tau()), One())), -100)
+	assertEqualAtPrecision(t, Zero(), ReduceMod2Pi(Multiply(FromInt(3), Tau())), -100)
+}
+
+func TestQuickSign(t *testing.T) {
+	assert.Equal(t, 1, QuickSign(Pi()))
+	assert.Equal(t, -1, QuickSign(Negate(E())))
+	assert.Equal(t, 1, QuickSign(ShiftRight(One(), 18)))
+
+	// too close to zero to tell at the default precision
+	assert.Equal(t, 0, QuickSign(ShiftRight(FromInt(1), 100)))
+	assert.Equal(t, 0, QuickSign(Zero()))
+}
+
+func TestNegationOperand(t *testing.T) {
+	r, ok := NegationOperand(Negate(Pi()))
+	assert.True(t, ok)
+	assert.Same(t, Pi(), r)
+
+	r, ok = NegationOperand(Named("x", Negate(E())))
+	assert.True(t, ok)
+	assert.Same(t, E(), r)
+
+	r, ok = NegationOperand(Pi())
+	assert.False(t, ok)
+	assert.Nil(t, r)
+}
+
+func TestAsSExpr(t *testing.T) {
+	c := Divide(Add(FromInt(1), FromInt(2)), FromInt(4))
+	s := AsSExpr(c)
+	assert.Equal(t, "(multiply (add (int 1) (int 2)) (inverse (int 4)))", s)
+
+	parsed, err := ParseSExpr(s)
+	assert.NoError(t, err)
+	assert.Equal(t, s, AsSExpr(parsed))
+	assertEqualAtPrecision(t, c, parsed, -100)
+
+	for _, c := range []Real{Pi(), Phi(), Abs(Negate(E())), Ln2(), ShiftRight(Sqrt2(), 3), FromRatExact(big.NewRat(-22, 7)), Zeta(5), Apery(), PiWith(GaussLegendre), LambertW(One()), NthRoot(FromInt(-10), 5)} {
+		parsed, err := ParseSExpr(AsSExpr(c))
+		if assert.NoError(t, err, AsSExpr(c)) {
+			assert.Equal(t, AsSExpr(c), AsSExpr(parsed))
+			assertEqualAtPrecision(t, c, parsed, -100)
+		}
+	}
+
+	assert.Equal(t, `(named "π" (multiply (int 4) (add (multiply (int 6) (arctan-inverse (int 8))) (add (multiply (int 2) (arctan-inverse (int 57))) (arctan-inverse (int 239))))))`, AsSExpr(Pi()))
+	assert.Equal(t, `(opaque "Series(Int(1))")`, AsSExpr(SeriesWithTail(expCoeff, FromInt(1), expTail)))
+}
+
+func TestParseSExpr_Invalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"(int 1",
+		"(int x)",
+		"(int 1) (int 2)",
+		"(add (int 1))",
+		"(series (int 1))",
+		`(opaque "Series(Int(1))")`,
+		"(named pi (int 3))",
+		"(shift (int 1) x)",
+		"(zeta 1)",
+		"(nth-root (int 2) 2)",
+		"int 1",
+	} {
+		_, err := ParseSExpr(s)
+		assert.ErrorIs(t, err, ErrInvalidSExpr, s)
+	}
+}
+
+func TestNum(t *testing.T) {
+	assertEqualAtPrecision(t, FromInt(20), Wrap(FromInt(2)).Add(Wrap(FromInt(3))).Mul(Wrap(FromInt(4))).Unwrap(), -100)
+	assertEqualAtPrecision(t, Phi(), Wrap(FromInt(5)).Sqrt().Add(Wrap(One())).Div(Wrap(Two())).Unwrap(), -100)
+	assertEqualAtPrecision(t, FromInt(3), Wrap(FromInt(-3)).Abs().Unwrap(), -100)
+	assertEqualAtPrecision(t, FromInt(-3), Wrap(FromInt(-3)).Min(Wrap(Pi())).Unwrap(), -100)
+	assertEqualAtPrecision(t, Pi(), Wrap(FromInt(-3)).Max(Wrap(Pi())).Unwrap(), -100)
+	assertEqualAtPrecision(t, FromRat(-1, 4), Wrap(FromInt(4)).Inv().Neg().Unwrap(), -100)
+	assertEqualAtPrecision(t, Two(), Wrap(Two()).Ln().Exp().Unwrap(), -100)
+	assertEqualAtPrecision(t, FromInt(8), Wrap(Two()).Pow(Wrap(FromInt(3))).Unwrap(), -100)
+	assertEqualAtPrecision(t, Zero(), Wrap(Pi()).Sub(Wrap(Pi())).Unwrap(), -100)
+}
+
+func TestCReal(t *testing.T) {
+	a, b := NewCReal(Pi()), NewCReal(FromRat(1, 3))
+	assert.Equal(t, AsConstruction(Add(Pi(), FromRat(1, 3))), AsConstruction(a.Add(b).Real()))
+	assert.Equal(t, AsConstruction(Multiply(Pi(), FromRat(1, 3))), AsConstruction(a.Multiply(b).Real()))
+	assert.Equal(t, AsConstruction(Sqrt(Pi())), AsConstruction(a.Sqrt().Real()))
+
+	assertEqualAtPrecision(t, Add(Pi(), FromRat(1, 3)), a.Add(b).Real(), -100)
+	assertEqualAtPrecision(t, Multiply(Pi(), FromRat(1, 3)), a.Multiply(b).Real(), -100)
+	assertEqualAtPrecision(t, Sqrt(Pi()), a.Sqrt().Real(), -100)
+	assertEqualAtPrecision(t, Subtract(Pi(), FromRat(1, 3)), a.Subtract(b).Real(), -100)
+	assertEqualAtPrecision(t, FromInt(3), b.Inverse().Real(), -100)
+	assertEqualAtPrecision(t, FromRat(4, 3), b.Negate().Abs().ShiftLeft(3).ShiftRight(1).Real(), -100)
+	assertEqualAtPrecision(t, Multiply(Pi(), FromInt(3)), a.Divide(b).Real(), -100)
+
+	assert.Equal(t, 1, a.Cmp(b))
+	assert.Equal(t, -1, b.Cmp(a))
+	assert.Equal(t, 0, a.PreciseCmp(NewCReal(Pi()), -100))
+	assert.Equal(t, "3.14159", a.Text(5, 10))
+	assert.Same(t, Pi(), a.Real())
+}
+
+type algebraicDegreeTest struct {
+	name     string
+	input    Real
+	expected int
+}
+
+var algebraicDegreeTests = []algebraicDegreeTest{
+	{"integer", FromInt(7), 1},
+	{"rational", FromRat(-22, 7), 1},
+	{"square root", Sqrt(FromInt(2)), 2},
+	{"named square root", Sqrt2(), 2},
+	{"golden ratio", Phi(), 2},
+	{"perfect square", Sqrt(FromInt(4)), 1},
+	{"rational square root", Sqrt(FromRat(9, 4)), 1},
+	{"square of root", Square(Sqrt2()), 1},
+	{"cancelling roots", Subtract(Sqrt(FromInt(8)), ShiftLeft(Sqrt2(), 1)), 1},
+	{"sum of roots", Add(Sqrt2(), Sqrt(FromInt(3))), 4},
+	{"product of roots", Multiply(Sqrt(FromInt(6)), Sqrt2()), 2},
+	{"three roots", Add(Add(Sqrt2(), Sqrt(FromInt(3))), Sqrt(FromInt(5))), 8},
+	{"inverse", Inverse(Add(One(), Sqrt2())), 2},
+	{"absolute value", Abs(Subtract(One(), Sqrt2())), 2},
+	{"large prime", Sqrt(FromInt64(1000000007)), 2},
+	{"nested root", Sqrt(Sqrt2()), 4},
+	{"denested root", Sqrt(Add(FromInt(3), ShiftLeft(Sqrt2(), 1))), 2},
+	{"cube root", NthRoot(Two(), 3), 3},
+	{"negative cube root", NthRoot(FromInt(-2), 3), 3},
+	{"rational cube root", NthRoot(FromInt(27), 3), 1},
+	{"sum of mixed roots", Add(Sqrt2(), NthRoot(Two(), 3)), 6},
+}
+
+func TestAlgebraicDegree(t *testing.T) {
+	for _, test := range algebraicDegreeTests {
+		t.Run(test.name, func(t *testing.T) {
+			degree, ok := AlgebraicDegree(test.input)
+			assert.True(t, ok)
+			assert.Equal(t, test.expected, degree)
+		})
+	}
+
+	for _, c := range []Real{Pi(), E(), Sqrt(FromInt(-1)), Add(NthRoot(Two(), 3), Pi()), Inverse(Subtract(Sqrt2(), Sqrt2()))} {
+		degree, ok := AlgebraicDegree(c)
+		assert.False(t, ok)
+		assert.Equal(t, 0, degree)
+	}
+}
+
+func ratStrings(rs []*big.Rat) []string {
+	ss := make([]string, len(rs))
+	for i, r := range rs {
+		ss[i] = r.RatString()
+	}
+	return ss
+}
+
+func TestMinimalPolynomial(t *testing.T) {
+	coeffs, ok := MinimalPolynomial(Phi())
+	assert.True(t, ok)
+	assert.Equal(t, []string{"-1", "-1", "1"}, ratStrings(coeffs))
+
+	for _, c := range []Real{Phi(), Sqrt2(), FromRat(3, 4), Add(FromRat(1, 3), Multiply(FromInt(5), Sqrt(FromInt(7)))), Add(Sqrt2(), Sqrt(FromInt(3)))} {
+		coeffs, ok := MinimalPolynomial(c)
+		if !assert.True(t, ok) {
+			continue
+		}
+
+		degree, _ := AlgebraicDegree(c)
+		assert.Len(t, coeffs, degree+1)
+		assert.Equal(t, "1", coeffs[degree].RatString())
+
+		cs := make([]Real, len(coeffs))
+		for i, r := range coeffs {
+			cs[i] = FromRatExact(r)
+		}
+		assertEqualAtPrecision(t, Zero(), PolyEval(cs, c), -100)
+	}
+
+	// √2 + √3 is a root of x⁴ - 10x² + 1
+	coeffs, _ = MinimalPolynomial(Add(Sqrt2(), Sqrt(FromInt(3))))
+	assert.Equal(t, []string{"1", "0", "-10", "0", "1"}, ratStrings(coeffs))
+
+	coeffs, _ = MinimalPolynomial(NthRoot(Two(), 3))
+	assert.Equal(t, []string{"-2", "0", "0", "1"}, ratStrings(coeffs))
+
+	coeffs, _ = MinimalPolynomial(Sqrt(Sqrt2()))
+	assert.Equal(t, []string{"-2", "0", "0", "0", "1"}, ratStrings(coeffs))
+
+	// √(3 + 2√2) = 1 + √2 is a root of x² - 2x - 1
+	coeffs, _ = MinimalPolynomial(Sqrt(Add(FromInt(3), ShiftLeft(Sqrt2(), 1))))
+	assert.Equal(t, []string{"-1", "-2", "1"}, ratStrings(coeffs))
+
+	_, ok = MinimalPolynomial(Pi())
+	assert.False(t, ok)
+}
+
+func TestAnnihilator_TinyCondsign(t *testing.T) {
+	// the operand of Abs is below 2^-4096, but provably non-zero, so the
+	// negative branch is taken: the result is a root of x³ - 2^-12599
+	p, ok := annihilator{}.of(Abs(Negate(ShiftRight(NthRoot(Two(), 3), 4200))))
+	if assert.True(t, ok) {
+		assert.Equal(t, 3, p.degree())
+		assert.Equal(t, -1, p[0].Sign())
+	}
+
+	// an even root of an operand that is not known to be non-negative
+	_, ok = annihilator{}.of(NthRoot(Subtract(NthRoot(Two(), 3), NthRoot(Two(), 3)), 4))
+	assert.False(t, ok)
+}
+
+func TestArctanReciprocal(t *testing.T) {
+	assertEqualAtPrecision(t, Pi(), Multiply(FromInt(4), ArctanReciprocal(1)), -30)
+	assertEqualAtPrecision(t, Pi(), Multiply(FromInt(4), ArctanReciprocal(1)), -200)
+
+	// Machin's formula: π = 16 arctan(1/5) - 4 arctan(1/239)
+	machin := Subtract(Multiply(FromInt(16), ArctanReciprocal(5)), Multiply(FromInt(4), ArctanReciprocal(239)))
+	assertEqualAtPrecision(t, Pi(), machin, -200)
+
+	assertEqualAtPrecision(t, Negate(ArctanReciprocal(2)), ArctanReciprocal(-2), -100)
+	assert.Equal(t, "0.46364760900080611621", Text(ArctanReciprocal(2), 20, 10))
+	assert.Nil(t, ArctanReciprocal(0))
+}
+
+func TestPiFromMachin(t *testing.T) {
+	formulas := [][]MachinTerm{
+		{{16, 5}, {-4, 239}},
+		{{24, 8}, {8, 57}, {4, 239}},
+		{{4, 1}},
+		{{48, 18}, {32, 57}, {-20, 239}},
+	}
+	for _, terms := range formulas {
+		pi, err := PiFromMachin(terms)
+		if assert.NoError(t, err, terms) {
+			assertEqualAtPrecision(t, Pi(), pi, -100)
+		}
+	}
+
+	// the formula used by Pi sums to π/4 when written without the factor of 4
+	for _, terms := range [][]MachinTerm{{{6, 8}, {2, 57}, {1, 239}}, {{16, 5}, {-4, 238}}, {{4, 0}}, nil} {
+		_, err := PiFromMachin(terms)
+		assert.ErrorIs(t, err, ErrNotPi, terms)
+	}
+}
+
+func TestScaleInt64(t *testing.T) {
+	values := []int64{0, 1, -1, 2, -2, 3, -3, 5, -5, 7, 1<<31 - 1, -1 << 31, 1<<62 - 1, -1 << 62, math.MaxInt64, math.MinInt64}
+	for _, v := range values {
+		for n := -70; n <= 70; n++ {
+			i := big.NewInt(v)
+			if appr, ok := scaleInt64(i, n); ok {
+				assert.Equal(t, scale(i, n).String(), appr.String(), "%d scaled by 2^%d", v, n)
+			}
+		}
+	}
+
+	_, ok := scaleInt64(big.NewInt(1<<40), 30)
+	assert.False(t, ok)
+	_, ok = scaleInt64(new(big.Int).Lsh(big.NewInt(1), 70), -10)
+	assert.False(t, ok)
+}
+
+func TestDecimalPrecision(t *testing.T) {
+	for decimals, expected := range map[int]int{0: 0, 1: -4, 2: -7, 3: -10, 8: -27, 100: -333, -2: 6} {
+		p := DecimalPrecision(decimals)
+		assert.Equal(t, expected, p, "%d decimals", decimals)
+
+		// 2^p <= 10^-decimals < 2^(p+1)
+		tenth := Pow10Int(-decimals)
+		assert.LessOrEqual(t, PreciseCmp(Pow2Int(p), tenth, -400), 0)
+		assert.Equal(t, 1, PreciseCmp(Pow2Int(p+1), tenth, -400))
+	}
+}
+
+func TestEqualToDecimals(t *testing.T) {
+	assert.True(t, EqualToDecimals(Pi(), FromFloat64(3.14159265), 8))
+	assert.False(t, EqualToDecimals(Pi(), FromFloat64(3.14159265), 9))
+
+	assert.True(t, EqualToDecimals(Pi(), PiWith(Chudnovsky), 300))
+	assert.True(t, EqualToDecimals(Subtract(Pi(), Pi()), Zero(), 1000))
+	assert.True(t, EqualToDecimals(FromRat(1, 3), FromFloat64(0.3333), 4))
+	assert.False(t, EqualToDecimals(FromRat(1, 3), FromFloat64(0.3333), 5))
+}
+
+func TestEContinuedFraction(t *testing.T) {
+	quotients := []int64{2, 1, 2, 1, 1, 4, 1, 1, 6, 1, 1, 8, 1, 1, 10}
+	for n, expected := range quotients {
+		assert.Equal(t, expected, eQuotient(n), "quotient %d", n)
+	}
+	assert.Equal(t, quotients, ToContinuedFraction(EContinuedFraction(), len(quotients)))
+
+	assertEqualAtPrecision(t, E(), EContinuedFraction(), -500)
+
+	// the first 41 terms, as a finite generalized continued fraction, already
+	// agree far beyond 2^-100
+	a := make([]Real, 40)
+	b := make([]Real, 41)
+	for n := range b {
+		if n > 0 {
+			a[n-1] = One()
+		}
+		b[n] = FromInt64(eQuotient(n))
+	}
+	assertEqualAtPrecision(t, GeneralizedContinuedFraction(a, b), EContinuedFraction(), -100)
+	assertEqualAtPrecision(t, E(), EContinuedFraction(), 2)
+	assertEqualAtPrecision(t, E(), EContinuedFraction(), -3)
+	assert.Equal(t, "2.71828182845904523536", Text(EContinuedFraction(), 20, 10))
+}
@@ -0,0 +1,76 @@
+package constructive
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+// EContinuedFraction calculates e from its simple continued fraction,
+//
+// e = [2; 1, 2, 1, 1, 4, 1, 1, 6, 1, ...]
+//
+// whose partial quotients follow the pattern 1, 2k, 1 after the first,
+// evaluated with GeneralizedContinuedFraction. It is an alternative to E,
+// which uses the Taylor series of e^x.
+var EContinuedFraction = sync.OnceValue(func() Real {
+	return newSimpleContinuedFraction("E", eQuotient)
+})
+
+// eQuotient returns the n-th partial quotient of the continued fraction of e.
+func eQuotient(n int) int64 {
+	switch {
+	case n == 0:
+		return 2
+	case n%3 == 2:
+		return int64(2 * (n + 1) / 3)
+	default:
+		return 1
+	}
+}
+
+// simpleContinuedFraction is the infinite simple continued fraction whose
+// n-th partial quotient is quotient(n), which must be positive for n ≥ 1. It
+// is approximated by truncating it, for each precision, to a finite
+// GeneralizedContinuedFraction with partial numerators of 1.
+type simpleContinuedFraction struct {
+	precisionTracker
+	name     string
+	quotient func(n int) int64
+}
+
+func newSimpleContinuedFraction(name string, quotient func(n int) int64) Real {
+	return &simpleContinuedFraction{
+		name:     name,
+		quotient: quotient,
+	}
+}
+
+// truncate computes the convergent hₙ/kₙ with the fewest terms such that kₙ²
+// exceeds 2^(2-p), as a GeneralizedContinuedFraction. Since the error of a
+// convergent is less than 1/kₙ², it is within 2^(p-2) of the value.
+func (c *simpleContinuedFraction) truncate(p int) Real {
+	// k₋₁ = 0, k₀ = 1
+	k0, k1 := big.NewInt(0), big.NewInt(1)
+	a := []Real{}
+	b := []Real{FromInt64(c.quotient(0))}
+	bound := bigLsh(big.NewInt(1), uint(max(2-p, 0)))
+	for n := 1; new(big.Int).Mul(k1, k1).Cmp(bound) <= 0; n++ {
+		q := c.quotient(n)
+		k0, k1 = k1, k0.Add(k0, bigMul(big.NewInt(q), k1))
+		a = append(a, One())
+		b = append(b, FromInt64(q))
+	}
+
+	return GeneralizedContinuedFraction(a, b)
+}
+
+// approximate approximates the truncation at precision p-2, which is then
+// within 2^(p-1) of the value, and rounding it adds at most half a unit.
+func (c *simpleContinuedFraction) approximate(p int) *big.Int {
+	return scale(Approximate(c.truncate(p), p-2), -2)
+}
+
+func (c *simpleContinuedFraction) asConstruction() string {
+	return fmt.Sprintf("ContinuedFraction(%s)", c.name)
+}
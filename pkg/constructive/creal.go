@@ -0,0 +1,86 @@
+package constructive
+
+// CReal exposes the operations on a Real number as methods, in the same
+// style as unified.Real and rational.Number, so that `r.Add(s)` can be
+// written instead of `Add(r, s)`. Unlike Num, whose short method names are
+// meant for chaining, the method names of CReal match the package functions.
+type CReal struct {
+	c Real
+}
+
+// NewCReal creates a CReal from the Real number c.
+func NewCReal(c Real) CReal {
+	return CReal{c: c}
+}
+
+// Real returns the underlying Real number.
+func (r CReal) Real() Real {
+	return r.c
+}
+
+// Add adds r and s, returning a new CReal.
+func (r CReal) Add(s CReal) CReal {
+	return NewCReal(Add(r.c, s.c))
+}
+
+// Subtract subtracts s from r, returning a new CReal.
+func (r CReal) Subtract(s CReal) CReal {
+	return NewCReal(Subtract(r.c, s.c))
+}
+
+// Multiply multiplies r by s, returning a new CReal.
+func (r CReal) Multiply(s CReal) CReal {
+	return NewCReal(Multiply(r.c, s.c))
+}
+
+// Divide divides r by s, returning a new CReal.
+func (r CReal) Divide(s CReal) CReal {
+	return NewCReal(Divide(r.c, s.c))
+}
+
+// Negate returns the negation of r.
+func (r CReal) Negate() CReal {
+	return NewCReal(Negate(r.c))
+}
+
+// Inverse returns the multiplicative inverse of r.
+func (r CReal) Inverse() CReal {
+	return NewCReal(Inverse(r.c))
+}
+
+// Abs returns the absolute value of r.
+func (r CReal) Abs() CReal {
+	return NewCReal(Abs(r.c))
+}
+
+// ShiftLeft multiplies r by 2^n.
+func (r CReal) ShiftLeft(n int) CReal {
+	return NewCReal(ShiftLeft(r.c, n))
+}
+
+// ShiftRight divides r by 2^n.
+func (r CReal) ShiftRight(n int) CReal {
+	return NewCReal(ShiftRight(r.c, n))
+}
+
+// Sqrt returns the square root of r.
+func (r CReal) Sqrt() CReal {
+	return NewCReal(Sqrt(r.c))
+}
+
+// Cmp compares r and s like the package function Cmp, and likewise never
+// terminates if they are equal.
+func (r CReal) Cmp(s CReal) int {
+	return Cmp(r.c, s.c)
+}
+
+// PreciseCmp compares r and s at precision p, like the package function
+// PreciseCmp.
+func (r CReal) PreciseCmp(s CReal, p int) int {
+	return PreciseCmp(r.c, s.c, p)
+}
+
+// Text formats r with dec digits after the point in the given radix.
+func (r CReal) Text(dec, radix int) string {
+	return Text(r.c, dec, radix)
+}
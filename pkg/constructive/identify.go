@@ -7,15 +7,191 @@ import (
 
 var ErrNotConstructive = errors.New("not constructive")
 
-func Identify(c Real) (*big.Int, bool, error) {
+// Identify attempts to determine the exact rational value of c by inspecting
+// its construction. It returns the value and true when c is built only from
+// integers using addition, multiplication, inversion, shifts, and negation;
+// otherwise, it returns false, which does not imply that c is irrational.
+// Rationals created by FromRatExact are identified immediately.
+func Identify(c Real) (*big.Rat, bool, error) {
 	if c == nil {
 		return nil, false, ErrNotConstructive
 	}
 
-	//switch v := c.(type) {
-	//case *constructiveInteger:
-	//	return NewRational(v.i, 1), true, nil
-	//}
+	r, ok := identify(c)
+	return r, ok, nil
+}
+
+// IsRational returns true if c is structurally rational, as determined by
+// Identify.
+func IsRational(c Real) bool {
+	_, ok, _ := Identify(c)
+	return ok
+}
+
+// SignExact computes the sign of c, and reports whether the sign was decided.
+// When c is structurally rational, as determined by Identify, its sign is
+// exact, including zero. Otherwise, the sign is computed with PreciseSign at
+// a precision of 2^-100, and is undecided when c is indistinguishable from
+// zero at that precision.
+func SignExact(c Real) (int, bool) {
+	if r, ok, _ := Identify(c); ok {
+		return r.Sign(), true
+	}
+
+	s := PreciseSign(c, zeroPrecision)
+	return s, s != 0
+}
+
+// SubtractExact computes a - b, and reports whether the difference is exact.
+// When both a and b are structurally rational, as determined by Identify,
+// the difference is computed exactly as a rational, so that, for example,
+// a - a is recognized as exactly zero. Otherwise, it returns Subtract(a, b)
+// and false.
+func SubtractExact(a, b Real) (Real, bool) {
+	ra, oka, _ := Identify(a)
+	rb, okb, _ := Identify(b)
+	if !oka || !okb {
+		return Subtract(a, b), false
+	}
+
+	return FromRatExact(ra.Sub(ra, rb)), true
+}
+
+// CmpExact compares a and b, and reports whether the comparison is exact.
+// When both a and b are structurally rational, as determined by Identify,
+// their rationals are compared exactly, including equality. Otherwise, they
+// are compared with PreciseCmp at a precision of 2^-100, which terminates,
+// but reports 0 for numbers that are merely indistinguishable at that
+// precision.
+func CmpExact(a, b Real) (int, bool) {
+	ra, oka, _ := Identify(a)
+	rb, okb, _ := Identify(b)
+	if oka && okb {
+		return ra.Cmp(rb), true
+	}
+
+	return PreciseCmp(a, b, zeroPrecision), false
+}
+
+// IsPowerOfTwo returns n and true when c is structurally rational, as
+// determined by Identify, and exactly equal to 2^n, such as FromInt(8) or
+// ShiftRight(One(), 3); otherwise, it returns false.
+func IsPowerOfTwo(c Real) (int, bool) {
+	r, ok, _ := Identify(c)
+	if !ok || r.Sign() <= 0 {
+		return 0, false
+	}
+
+	num, denom := r.Num(), r.Denom()
+	switch {
+	case denom.IsInt64() && denom.Int64() == 1 && isPowerOfTwo(num):
+		return num.BitLen() - 1, true
+	case num.IsInt64() && num.Int64() == 1 && isPowerOfTwo(denom):
+		return 1 - denom.BitLen(), true
+	}
+	return 0, false
+}
+
+// isPowerOfTwo reports whether the positive integer i is a power of two.
+func isPowerOfTwo(i *big.Int) bool {
+	return int(i.TrailingZeroBits()) == i.BitLen()-1
+}
+
+func identify(c Real) (*big.Rat, bool) {
+	return rationalIdentifier{}.identify(c)
+}
+
+// identifiedRational is the result of identifying a node as a rational.
+type identifiedRational struct {
+	r  *big.Rat
+	ok bool
+}
+
+// rationalIdentifier memoizes the result of identifying each node during one
+// call to Identify, so that a construction sharing subtrees, such as
+// x = Multiply(x, Inverse(x)) applied repeatedly, is walked in time linear in
+// the number of distinct nodes rather than the size of the expanded tree.
+type rationalIdentifier map[Real]identifiedRational
+
+func (m rationalIdentifier) identify(c Real) (*big.Rat, bool) {
+	c = Unwrap(c)
+	if v, ok := m[c]; ok {
+		return v.r, v.ok
+	}
+
+	r, ok := m.walk(c)
+	m[c] = identifiedRational{r: r, ok: ok}
+	return r, ok
+}
+
+// walk identifies c from the identities of its operands. The rationals in m
+// are shared between nodes, so they must not be modified.
+func (m rationalIdentifier) walk(c Real) (*big.Rat, bool) {
+	switch v := c.(type) {
+	case *constructiveInteger:
+		return new(big.Rat).SetInt(v.i), true
+	case *constructiveRational:
+		return v.Rat(), true
+	case *constructiveAddition:
+		a, ok := m.identify(v.a)
+		if !ok {
+			return nil, false
+		}
+		b, ok := m.identify(v.b)
+		if !ok {
+			return nil, false
+		}
+		return new(big.Rat).Add(a, b), true
+	case *constructiveMultiplication:
+		a, aok := m.identify(v.a)
+		if aok && a.Sign() == 0 {
+			return a, true
+		}
+		b, bok := m.identify(v.b)
+		if bok && b.Sign() == 0 {
+			return b, true
+		}
+		if !aok || !bok {
+			return nil, false
+		}
+		return new(big.Rat).Mul(a, b), true
+	case *constructiveMultiplicativeInverse:
+		r, ok := m.identify(v.r)
+		if !ok || r.Sign() == 0 {
+			return nil, false
+		}
+		return new(big.Rat).Inv(r), true
+	case *constructiveShift:
+		r, ok := m.identify(v.r)
+		if !ok {
+			return nil, false
+		}
+		if v.n >= 0 {
+			return new(big.Rat).Mul(r, new(big.Rat).SetInt(bigLsh(big.NewInt(1), uint(v.n)))), true
+		}
+		return new(big.Rat).Quo(r, new(big.Rat).SetInt(bigLsh(big.NewInt(1), uint(-v.n)))), true
+	case *constructiveNegation:
+		r, ok := m.identify(v.r)
+		if !ok {
+			return nil, false
+		}
+		return new(big.Rat).Neg(r), true
+	case *constructiveCondsign:
+		r, ok := m.identify(v.r)
+		if !ok {
+			return nil, false
+		}
+		a, aok := m.identify(v.a)
+		b, bok := m.identify(v.b)
+		switch {
+		case r.Sign() < 0 && aok:
+			return a, true
+		case r.Sign() > 0 && bok:
+			return b, true
+		case r.Sign() == 0 && aok && bok && a.Cmp(b) == 0:
+			return a, true
+		}
+	}
 
-	return nil, true, nil
+	return nil, false
 }
@@ -5,9 +5,11 @@ import (
 	"math/big"
 )
 
+// bigOne is the constant 1, which must never be modified.
+var bigOne = big.NewInt(1)
+
 //var (
 //	bigZero  = big.NewInt(0)
-//	bigOne   = big.NewInt(1)
 //	bigTwo   = big.NewInt(2)
 //	bigThree = big.NewInt(3)
 //	bigFour  = big.NewInt(4)
@@ -0,0 +1,32 @@
+package constructive
+
+// Integrate approximates the definite integral of f from a to b using the
+// composite Simpson's rule with n subintervals, where n must be positive and
+// even, or nil is returned:
+//
+// ∫f ≈ h/3 (f(x₀) + 4f(x₁) + 2f(x₂) + ... + 4f(xₙ₋₁) + f(xₙ))
+//
+// where h = (b-a)/n and xᵢ = a + ih. The weighted sum is computed exactly from
+// the samples of f, so the only error is that of Simpson's rule itself, which
+// is exact for polynomials of degree three or less.
+func Integrate(f func(Real) Real, a, b Real, n int) Real {
+	if n <= 0 || n%2 != 0 {
+		return nil
+	}
+
+	h := Divide(Subtract(b, a), FromInt(n))
+	terms := make([]Real, 0, n+1)
+	for i := 0; i <= n; i++ {
+		x := Add(a, Multiply(FromInt(i), h))
+		switch {
+		case i == 0 || i == n:
+			terms = append(terms, f(x))
+		case i%2 == 1:
+			terms = append(terms, ShiftLeft(f(x), 2))
+		default:
+			terms = append(terms, ShiftLeft(f(x), 1))
+		}
+	}
+
+	return Multiply(Divide(h, FromInt(3)), Sum(terms...))
+}
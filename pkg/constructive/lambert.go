@@ -0,0 +1,119 @@
+package constructive
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// LambertW computes the principal branch W₀ of the Lambert W function, which
+// is the solution w ≥ -1 of `w * e^w = c`, for `c ≥ -1/e`. It returns nil
+// when c is less than -1/e.
+//
+// Convergence slows as c approaches the branch point -1/e, where the
+// derivative of `w * e^w` vanishes; at exactly -1/e it may not terminate.
+func LambertW(c Real) Real {
+	if PreciseCmp(c, Negate(Inverse(E())), -50) < 0 {
+		return nil
+	}
+
+	return newLambertW(c)
+}
+
+type lambertW struct {
+	precisionTracker
+	r Real
+}
+
+func newLambertW(c Real) Real {
+	return &lambertW{
+		r: c,
+	}
+}
+
+// approximate refines a float64 estimate of W(c) using Newton's method,
+//
+// wₙ₊₁ = wₙ - (wₙe^wₙ - c)/(e^wₙ(wₙ+1))
+//
+// where each wₙ is held exactly as a dyadic rational. Since the method
+// converges quadratically, the precision is doubled with every step.
+func (c *lambertW) approximate(p int) *big.Int {
+	prec := -40
+	w := dyadic(Approximate(FromFloat64(lambertWSeed(c.r)), prec), prec)
+
+	// The seed may be poor for very large arguments, so iterate at the
+	// starting precision until it settles before relying on quadratic
+	// convergence.
+	for i := 0; i < 100; i++ {
+		next := Approximate(c.step(w), prec)
+		settled := bigSub(next, Approximate(w, prec)).CmpAbs(big.NewInt(1)) <= 0
+		w = dyadic(next, prec)
+		if settled {
+			break
+		}
+	}
+
+	for prec > p-8 {
+		prec = max(2*prec, p-8)
+		w = dyadic(Approximate(c.step(w), prec), prec)
+	}
+
+	return scale(Approximate(c.step(w), p-2), -2)
+}
+
+// step computes a single Newton step from w.
+func (c *lambertW) step(w Real) Real {
+	ew := Exp(w)
+	num := Subtract(Multiply(w, ew), c.r)
+	den := Multiply(ew, Add(w, One()))
+	return Subtract(w, Divide(num, den))
+}
+
+func (c *lambertW) asConstruction() string {
+	return fmt.Sprintf("LambertW(%s)", c.r.asConstruction())
+}
+
+// lambertWSeed estimates W(c) in float64 using Halley's method.
+func lambertWSeed(c Real) float64 {
+	x, _ := new(big.Float).SetMantExp(new(big.Float).SetInt(Approximate(c, -60)), -60).Float64()
+	if math.IsInf(x, 1) {
+		// W(x) ≈ ln(x) - ln(ln(x)) for large x
+		l := float64(msd(c, -60)) * math.Ln2
+		return l - math.Log(l)
+	}
+
+	var w float64
+	switch {
+	case x <= -1/math.E:
+		return -1
+	case x < -0.25:
+		// branch point expansion, W(x) ≈ -1 + q - q²/3 for q = √(2(ex+1))
+		q := math.Sqrt(2 * (math.E*x + 1))
+		w = -1 + q - q*q/3
+	case x < 3:
+		w = math.Log1p(x)
+	default:
+		l := math.Log(x)
+		w = l - math.Log(l)
+	}
+
+	for i := 0; i < 64; i++ {
+		ew := math.Exp(w)
+		f := w*ew - x
+		wn := w - f/(ew*(w+1)-(w+2)*f/(2*w+2))
+		if math.IsNaN(wn) || math.IsInf(wn, 0) {
+			break
+		}
+		if math.Abs(wn-w) <= 1e-15*(1+math.Abs(wn)) {
+			return wn
+		}
+		w = wn
+	}
+
+	return w
+}
+
+// dyadic creates the exact Real number i * 2^p.
+func dyadic(i *big.Int, p int) Real {
+	return ShiftLeft(FromBigInt(i), p)
+}
@@ -0,0 +1,194 @@
+package constructive
+
+import (
+	"fmt"
+	"strings"
+)
+
+// latexNames maps the names of the package constants to their LaTeX
+// notation. Other names are typeset upright, with \mathrm.
+var latexNames = map[string]string{
+	"π":    `\pi`,
+	"τ":    `\tau`,
+	"φ":    `\varphi`,
+	"√2":   `\sqrt{2}`,
+	"ln2":  `\ln 2`,
+	"ζ(3)": `\zeta(3)`,
+}
+
+// Precedence levels of LaTeX output; an operand whose own level is lower
+// than the level required by its context is wrapped in parentheses.
+const (
+	latexSum = iota
+	latexProduct
+	latexAtom
+)
+
+// AsLaTeX renders the construction of c as LaTeX math, such as
+// `\frac{1 + \sqrt{5}}{2}`. Named nodes are rendered by their name, so that
+// AsLaTeX(Phi()) is `\varphi`; use Unwrap to render the construction behind
+// the name instead.
+func AsLaTeX(c Real) string {
+	sb := &strings.Builder{}
+	latex(sb, c, latexSum)
+	return sb.String()
+}
+
+// latex writes c to sb, wrapping it in parentheses if it binds less tightly
+// than prec.
+func latex(sb *strings.Builder, c Real, prec int) {
+	if latexPrecedence(c) < prec {
+		sb.WriteString(`\left(`)
+		latexNode(sb, c)
+		sb.WriteString(`\right)`)
+		return
+	}
+
+	latexNode(sb, c)
+}
+
+func latexPrecedence(c Real) int {
+	switch v := c.(type) {
+	case *constructiveInteger:
+		if v.i.Sign() < 0 {
+			return latexSum
+		}
+	case *constructiveRational:
+		if v.r.Sign() < 0 {
+			return latexSum
+		}
+	case *constructiveAddition, *constructiveNegation:
+		return latexSum
+	case *constructiveMultiplication:
+		if _, ok := v.b.(*constructiveMultiplicativeInverse); !ok {
+			return latexProduct
+		}
+	case *constructiveShift:
+		if v.n > 0 {
+			return latexProduct
+		}
+	}
+	return latexAtom
+}
+
+func latexNode(sb *strings.Builder, c Real) {
+	switch v := c.(type) {
+	case nil:
+		sb.WriteString(`\mathrm{nil}`)
+	case *named:
+		if name, ok := latexNames[v.Name]; ok {
+			sb.WriteString(name)
+		} else if _, ok := Unwrap(v).(*constructiveInteger); ok {
+			sb.WriteString(v.Name)
+		} else {
+			sb.WriteString(`\mathrm{` + v.Name + `}`)
+		}
+	case *constructiveInteger:
+		sb.WriteString(v.i.String())
+	case *constructiveRational:
+		if v.r.Sign() < 0 {
+			sb.WriteString("-")
+		}
+		fmt.Fprintf(sb, `\frac{%s}{%s}`, bigAbs(v.r.Num()), v.r.Denom())
+	case *constructiveAddition:
+		latex(sb, v.a, latexSum)
+		if n, ok := v.b.(*constructiveNegation); ok {
+			sb.WriteString(" - ")
+			latex(sb, n.r, latexProduct)
+		} else {
+			sb.WriteString(" + ")
+			latex(sb, v.b, latexSum)
+		}
+	case *constructiveNegation:
+		sb.WriteString("-")
+		latex(sb, v.r, latexProduct)
+	case *constructiveMultiplication:
+		if inv, ok := v.b.(*constructiveMultiplicativeInverse); ok {
+			latexFrac(sb, v.a, inv.r)
+		} else {
+			latex(sb, v.a, latexProduct)
+			sb.WriteString(` \cdot `)
+			latex(sb, v.b, latexProduct)
+		}
+	case *constructiveMultiplicativeInverse:
+		latexFrac(sb, One(), v.r)
+	case *constructiveShift:
+		if v.n > 0 {
+			latex(sb, v.r, latexProduct)
+			fmt.Fprintf(sb, ` \cdot 2^{%d}`, v.n)
+		} else {
+			sb.WriteString(`\frac{`)
+			latex(sb, v.r, latexSum)
+			fmt.Fprintf(sb, `}{2^{%d}}`, -v.n)
+		}
+	case *constructiveCondsign:
+		sb.WriteString(`\begin{cases} `)
+		latex(sb, v.a, latexSum)
+		sb.WriteString(` & `)
+		latex(sb, v.r, latexSum)
+		sb.WriteString(` < 0 \\ `)
+		latex(sb, v.b, latexSum)
+		sb.WriteString(` & \text{otherwise} \end{cases}`)
+	case *prescaledExponential:
+		sb.WriteString(`e^{`)
+		latex(sb, v.r, latexSum)
+		sb.WriteString(`}`)
+	case *prescaledNaturalLog:
+		sb.WriteString(`\ln\left(1 + `)
+		latex(sb, v.r, latexSum)
+		sb.WriteString(`\right)`)
+	case *prescaledSqrt:
+		sb.WriteString(`\sqrt{`)
+		latex(sb, v.r, latexSum)
+		sb.WriteString(`}`)
+	case *nthRoot:
+		fmt.Fprintf(sb, `\sqrt[%d]{`, v.n)
+		latex(sb, v.r, latexSum)
+		sb.WriteString(`}`)
+	case *prescaledCosine:
+		latexCall(sb, `\cos`, v.r)
+	case *integralArctan:
+		sb.WriteString(`\arctan\left(`)
+		latexFrac(sb, One(), v.a)
+		sb.WriteString(`\right)`)
+	case *lambertW:
+		latexCall(sb, `W`, v.r)
+	case *powerSeries:
+		sb.WriteString(`\sum_{n=0}^{\infty} a_n `)
+		latex(sb, v.x, latexAtom)
+		sb.WriteString(`^{n}`)
+	case *zetaSeries:
+		fmt.Fprintf(sb, `\zeta(%d)`, v.s)
+	case *aperySeries:
+		sb.WriteString(`\zeta(3)`)
+	case *bisectionRoot:
+		v.mu.Lock()
+		a, b := v.a, v.b
+		v.mu.Unlock()
+
+		sb.WriteString(`\operatorname{root}\left[`)
+		latex(sb, a, latexSum)
+		sb.WriteString(", ")
+		latex(sb, b, latexSum)
+		sb.WriteString(`\right]`)
+	default:
+		fmt.Fprintf(sb, `\text{%T}`, v)
+	}
+}
+
+// latexFrac writes the fraction num / den to sb.
+func latexFrac(sb *strings.Builder, num, den Real) {
+	sb.WriteString(`\frac{`)
+	latex(sb, num, latexSum)
+	sb.WriteString(`}{`)
+	latex(sb, den, latexSum)
+	sb.WriteString(`}`)
+}
+
+// latexCall writes c as the argument of the function op.
+func latexCall(sb *strings.Builder, op string, c Real) {
+	sb.WriteString(op)
+	sb.WriteString(`\left(`)
+	latex(sb, c, latexSum)
+	sb.WriteString(`\right)`)
+}
@@ -0,0 +1,59 @@
+package constructive
+
+// GeoMean computes the geometric mean ⁿ√(Π cᵢ) of n positive numbers. It
+// returns nil when cs is empty, or when any element is not positive, as
+// decided at a precision of 2^-100.
+func GeoMean(cs []Real) Real {
+	if len(cs) == 0 {
+		return nil
+	}
+	for _, c := range cs {
+		if PreciseSign(c, zeroPrecision) <= 0 {
+			return nil
+		}
+	}
+
+	return NthRoot(Product(cs...), len(cs))
+}
+
+// HarmonicMean computes the harmonic mean n / Σ(1/cᵢ) of n numbers. It returns
+// nil when cs is empty, or when any element is indistinguishable from zero at
+// a precision of 2^-100.
+func HarmonicMean(cs []Real) Real {
+	invs := reciprocals(cs)
+	if invs == nil {
+		return nil
+	}
+
+	return Divide(FromInt(len(cs)), Sum(invs...))
+}
+
+// ParallelSum computes the reciprocal sum 1 / Σ(1/cᵢ), as for resistors in
+// parallel. Like HarmonicMean, it returns nil when cs is empty, or when any
+// element is indistinguishable from zero.
+func ParallelSum(cs []Real) Real {
+	invs := reciprocals(cs)
+	if invs == nil {
+		return nil
+	}
+
+	return Inverse(Sum(invs...))
+}
+
+// reciprocals computes the inverse of each of cs, or nil if cs is empty or
+// any of them is indistinguishable from zero.
+func reciprocals(cs []Real) []Real {
+	if len(cs) == 0 {
+		return nil
+	}
+
+	invs := make([]Real, len(cs))
+	for i, c := range cs {
+		inv, err := InverseErr(c)
+		if err != nil {
+			return nil
+		}
+		invs[i] = inv
+	}
+	return invs
+}
@@ -0,0 +1,83 @@
+package constructive
+
+// Num wraps a Real number to allow operations to be chained fluently, as in
+// Wrap(a).Add(Wrap(b)).Mul(Wrap(c)).Sqrt(), rather than nested as function
+// calls. Each method forwards to the package function of the same purpose.
+type Num struct {
+	r Real
+}
+
+// Wrap wraps c as a Num.
+func Wrap(c Real) Num {
+	return Num{r: c}
+}
+
+// Unwrap returns the Real number wrapped by n.
+func (n Num) Unwrap() Real {
+	return n.r
+}
+
+// Add returns n + m.
+func (n Num) Add(m Num) Num {
+	return Wrap(Add(n.r, m.r))
+}
+
+// Sub returns n - m.
+func (n Num) Sub(m Num) Num {
+	return Wrap(Subtract(n.r, m.r))
+}
+
+// Mul returns n * m.
+func (n Num) Mul(m Num) Num {
+	return Wrap(Multiply(n.r, m.r))
+}
+
+// Div returns n / m.
+func (n Num) Div(m Num) Num {
+	return Wrap(Divide(n.r, m.r))
+}
+
+// Neg returns -n.
+func (n Num) Neg() Num {
+	return Wrap(Negate(n.r))
+}
+
+// Inv returns 1 / n.
+func (n Num) Inv() Num {
+	return Wrap(Inverse(n.r))
+}
+
+// Abs returns |n|.
+func (n Num) Abs() Num {
+	return Wrap(Abs(n.r))
+}
+
+// Min returns the lesser of n and m.
+func (n Num) Min(m Num) Num {
+	return Wrap(Min(n.r, m.r))
+}
+
+// Max returns the greater of n and m.
+func (n Num) Max(m Num) Num {
+	return Wrap(Max(n.r, m.r))
+}
+
+// Sqrt returns the square root of n.
+func (n Num) Sqrt() Num {
+	return Wrap(Sqrt(n.r))
+}
+
+// Exp returns e^n.
+func (n Num) Exp() Num {
+	return Wrap(Exp(n.r))
+}
+
+// Ln returns the natural logarithm of n.
+func (n Num) Ln() Num {
+	return Wrap(Ln(n.r))
+}
+
+// Pow returns n^m.
+func (n Num) Pow(m Num) Num {
+	return Wrap(Pow(n.r, m.r))
+}
@@ -0,0 +1,180 @@
+package constructive
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+var ErrNotPi = errors.New("formula does not sum to π")
+
+// PiAlgorithm selects the algorithm used by PiWith to compute π.
+type PiAlgorithm int
+
+const (
+	// MachinLike computes π from arctangents of reciprocal integers, and is
+	// the algorithm used by Pi.
+	MachinLike PiAlgorithm = iota
+	// Chudnovsky computes π from the Chudnovsky series, which gains about 14
+	// decimal digits per term.
+	Chudnovsky
+	// GaussLegendre computes π with the Gauss–Legendre iteration, which
+	// doubles the number of correct digits on every step.
+	GaussLegendre
+)
+
+// PiWith calculates π using the given algorithm, or returns nil if the
+// algorithm is unknown. All algorithms compute the same number; they differ
+// only in how quickly they approximate it at different precisions.
+func PiWith(algo PiAlgorithm) Real {
+	switch algo {
+	case MachinLike:
+		return Pi()
+	case Chudnovsky:
+		return chudnovskyPi()
+	case GaussLegendre:
+		return gaussLegendrePi()
+	default:
+		return nil
+	}
+}
+
+// MachinTerm is the term Coefficient · arctan(1/Reciprocal) of a Machin-like
+// formula for π.
+type MachinTerm struct {
+	Coefficient int
+	Reciprocal  int
+}
+
+// machinCheckPrecision is the precision at which PiFromMachin checks that a
+// formula sums to π.
+const machinCheckPrecision = -64
+
+// PiFromMachin calculates π using the Machin-like formula given by terms,
+//
+// π = Σ Coefficient · arctan(1/Reciprocal)
+//
+// such as {{16, 5}, {-4, 239}} for Machin's original formula, or
+// {{24, 8}, {8, 57}, {4, 239}} for the formula used by Pi. Since a mistyped
+// formula would silently compute the wrong number, the sum is checked
+// against Pi at a precision of 2^-64, and ErrNotPi is returned if it
+// differs. A Reciprocal of zero also results in ErrNotPi.
+func PiFromMachin(terms []MachinTerm) (Real, error) {
+	sum := make([]Real, len(terms))
+	for i, term := range terms {
+		arctan := ArctanReciprocal(term.Reciprocal)
+		if arctan == nil {
+			return nil, fmt.Errorf("%w: term %d has a zero reciprocal", ErrNotPi, i)
+		}
+		sum[i] = Multiply(FromInt(term.Coefficient), arctan)
+	}
+
+	pi := Sum(sum...)
+	if PreciseCmp(pi, Pi(), machinCheckPrecision) != 0 {
+		return nil, fmt.Errorf("%w: sum is %s", ErrNotPi, Text(pi, 10, 10))
+	}
+	return pi, nil
+}
+
+// chudnovskyPi calculates π = 426880 √10005 / S, where S is the sum computed
+// by chudnovskySeries.
+var chudnovskyPi = sync.OnceValue(func() Real {
+	return Divide(Multiply(FromInt(426880), Sqrt(FromInt(10005))), newChudnovskySeries())
+})
+
+var gaussLegendrePi = sync.OnceValue(func() Real {
+	return &gaussLegendre{}
+})
+
+// chudnovskyBase is 640320³, the magnitude of the ratio of the powers in the
+// denominators of consecutive terms of the Chudnovsky series.
+var chudnovskyBase = big.NewInt(262537412640768000)
+
+type chudnovskySeries struct {
+	precisionTracker
+}
+
+func newChudnovskySeries() Real {
+	return &chudnovskySeries{}
+}
+
+// approximate computes the sum of the Chudnovsky series:
+//
+// S = Σ (6k)! (13591409 + 545140134k) / ((3k)! (k!)³ (-640320)^(3k))
+//
+// whose terms shrink by a factor of about 2^47 each. Each term is computed
+// from its exact numerator and denominator, so that the rounding errors do
+// not accumulate from one term to the next.
+func (c *chudnovskySeries) approximate(p int) *big.Int {
+	iters := max(-p, 0)/47 + 2
+	calcPrec := seriesPrecision(min(p, 0), iters)
+
+	k := 0
+	// m = (6k)! / ((3k)! (k!)³), which is always an integer, and x = (-640320³)^k
+	m := big.NewInt(1)
+	x := big.NewInt(1)
+	term := func() *big.Int {
+		t := big.NewInt(545140134)
+		t.Mul(t, big.NewInt(int64(k)))
+		t.Add(t, big.NewInt(13591409))
+		t.Mul(t, m)
+		t.Lsh(t, uint(-calcPrec))
+		return t.Quo(t, x)
+	}
+
+	return sumSeries(p, calcPrec, term(), func(t *big.Int) {
+		m.Mul(m, big.NewInt(int64((12*k+2)*(12*k+6)*(12*k+10))))
+		m.Quo(m, new(big.Int).Exp(big.NewInt(int64(k+1)), big.NewInt(3), nil))
+		x.Mul(x, chudnovskyBase)
+		x.Neg(x)
+		k++
+		t.Set(term())
+	})
+}
+
+func (c *chudnovskySeries) asConstruction() string {
+	return "ChudnovskySeries()"
+}
+
+type gaussLegendre struct {
+	precisionTracker
+}
+
+// approximate computes π using the Gauss–Legendre iteration, starting with
+// a = 1, b = 1/√2, t = 1/4:
+//
+// a' = (a + b) / 2, b' = √(ab), t' = t - 2ᵏ (a - a')²
+//
+// with π ≈ (a + b)² / 4t once a and b agree. The iteration is carried out in
+// fixed point with enough guard bits to absorb the rounding errors, which
+// are amplified by 2ᵏ in t.
+func (c *gaussLegendre) approximate(p int) *big.Int {
+	calcPrec := min(p, 0) - 2*boundLog2(-min(p, 0)) - 16
+	one := bigLsh(big.NewInt(1), uint(-calcPrec))
+
+	a := new(big.Int).Set(one)
+	b := new(big.Int).Sqrt(bigLsh(big.NewInt(1), uint(-2*calcPrec-1)))
+	t := new(big.Int).Rsh(one, 2)
+	d := new(big.Int)
+	for k := 0; d.Sub(a, b).CmpAbs(big.NewInt(1)) > 0; k++ {
+		an := new(big.Int).Add(a, b)
+		an.Rsh(an, 1)
+		b.Sqrt(b.Mul(a, b))
+
+		d.Sub(a, an)
+		d.Mul(d, d)
+		d.Rsh(d, uint(-calcPrec))
+		t.Sub(t, d.Lsh(d, uint(k)))
+		a = an
+	}
+
+	// (a + b)² carries a scale of 2^(-2 calcPrec), and t one of 2^-calcPrec
+	num := new(big.Int).Add(a, b)
+	num.Mul(num, num)
+	return scale(num.Quo(num, t.Lsh(t, 2)), calcPrec-p)
+}
+
+func (c *gaussLegendre) asConstruction() string {
+	return "GaussLegendre()"
+}
@@ -0,0 +1,20 @@
+package constructive
+
+// PolyEval evaluates the polynomial with the given coefficients at x, where
+// coeffs[i] is the coefficient of x^i, using Horner's method:
+//
+// c₀ + x(c₁ + x(c₂ + ... + x(cₙ)))
+//
+// An empty polynomial evaluates to zero.
+func PolyEval(coeffs []Real, x Real) Real {
+	if len(coeffs) == 0 {
+		return Zero()
+	}
+
+	r := coeffs[len(coeffs)-1]
+	for i := len(coeffs) - 2; i >= 0; i-- {
+		r = Add(coeffs[i], Multiply(x, r))
+	}
+
+	return r
+}
@@ -1,19 +1,52 @@
 package constructive
 
-import "math/big"
+import (
+	"math/big"
+	"sync"
+)
 
 // precisionTracker tracks the minimum precision (more negative is more precise)
 // and the maximum approximation of a Real number. The tracker is used
 // by embedding in a struct that implements the Real interface, on which
 // the `tracker` function can be called.
+//
+// The position of the most significant digit, which is derived from the
+// approximation, is memoized until the next approximation is set.
+//
+// The tracker is safe for concurrent use. Concurrent computations of the same
+// approximation are deduplicated, so that only one caller does the work while
+// the others wait for its result.
 type precisionTracker struct {
+	mu sync.Mutex
+
 	IsValid bool
 
 	MaxApproximation *big.Int
 	MinPrecision     int
+
+	msdValid bool
+	msd      int
+
+	inflight map[int]*approximationCall
+}
+
+// approximationCall is an in-flight computation of an approximation.
+type approximationCall struct {
+	done chan struct{}
+
+	result     *big.Int
+	panicked   bool
+	panicValue any
 }
 
 func (t *precisionTracker) Get(p int) (*big.Int, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.get(p)
+}
+
+func (t *precisionTracker) get(p int) (*big.Int, bool) {
 	if t.IsValid && p >= t.MinPrecision {
 		return scale(t.MaxApproximation, t.MinPrecision-p), true
 	}
@@ -22,13 +55,116 @@ func (t *precisionTracker) Get(p int) (*big.Int, bool) {
 }
 
 func (t *precisionTracker) Set(p int, i *big.Int) *big.Int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.set(p, i)
+}
+
+func (t *precisionTracker) set(p int, i *big.Int) *big.Int {
 	t.IsValid = true
 	t.MaxApproximation = i
 	t.MinPrecision = p
+	t.msdValid = false
 
 	return i
 }
 
+// Compute returns the approximation at precision p, calling fn to compute it
+// when it is not already cached. If another goroutine is already computing the
+// approximation at p, Compute waits for and returns its result instead, or
+// re-panics if that computation panicked.
+func (t *precisionTracker) Compute(p int, fn func(int) *big.Int) *big.Int {
+	t.mu.Lock()
+	if s, ok := t.get(p); ok {
+		t.mu.Unlock()
+		return s
+	}
+
+	if call, ok := t.inflight[p]; ok {
+		t.mu.Unlock()
+		<-call.done
+		if call.panicked {
+			panic(call.panicValue)
+		}
+		return call.result
+	}
+
+	call := &approximationCall{
+		done: make(chan struct{}),
+	}
+	if t.inflight == nil {
+		t.inflight = map[int]*approximationCall{}
+	}
+	t.inflight[p] = call
+	t.mu.Unlock()
+
+	defer func() {
+		if r := recover(); r != nil {
+			call.panicked = true
+			call.panicValue = r
+		}
+
+		t.mu.Lock()
+		delete(t.inflight, p)
+		// a finer approximation may have been cached in the meantime
+		if !call.panicked && (!t.IsValid || p < t.MinPrecision) {
+			t.set(p, call.result)
+		}
+		t.mu.Unlock()
+
+		close(call.done)
+		if call.panicked {
+			panic(call.panicValue)
+		}
+	}()
+
+	call.result = fn(p)
+	return call.result
+}
+
+// ComputeLocked is like Compute, but calls fn while holding the tracker's lock
+// instead of coordinating concurrent callers. It is meant for nodes, such as
+// integers, whose approximations are cheaper to compute than to coordinate;
+// fn must not approximate the node itself.
+func (t *precisionTracker) ComputeLocked(p int, fn func(int) *big.Int) *big.Int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if s, ok := t.get(p); ok {
+		return s
+	}
+
+	return t.set(p, fn(p))
+}
+
+// Approximation returns the tracked approximation and its precision, and
+// whether the tracker holds a valid approximation at all.
+func (t *precisionTracker) Approximation() (*big.Int, int, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.MaxApproximation, t.MinPrecision, t.IsValid
+}
+
+// MSD returns the position of the most significant digit of the tracked
+// approximation, which must be valid, computing it only once per approximation.
+func (t *precisionTracker) MSD() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.msdValid {
+		t.msd = t.computeMSD()
+		t.msdValid = true
+	}
+
+	return t.msd
+}
+
+func (t *precisionTracker) computeMSD() int {
+	return t.MinPrecision + bigAbs(t.MaxApproximation).BitLen() - 1
+}
+
 func (t *precisionTracker) tracker() *precisionTracker {
 	return t
 }
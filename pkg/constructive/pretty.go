@@ -7,16 +7,76 @@ import (
 
 func Pretty(c Real) string {
 	sb := &strings.Builder{}
-	pretty(sb, c)
+	pretty(sb, c, nil)
 	return sb.String()
 }
 
-func pretty(sb *strings.Builder, c Real) {
+// PrettyShared is like Pretty, but nodes referenced more than once in the
+// construction are bound once with `let xN = …` and referred to by name, so
+// that sharing is visible and large DAGs do not blow up. Bindings are listed
+// one per line, dependencies first, followed by the expression itself.
+func PrettyShared(c Real) string {
+	refs := map[Real]int{}
+	var count func(Real)
+	count = func(c Real) {
+		if c == nil {
+			return
+		}
+
+		refs[c]++
+		if _, ok := c.(*named); ok || refs[c] > 1 {
+			return
+		}
+		for _, o := range operands(c) {
+			count(o)
+		}
+	}
+	count(c)
+
+	sb := &strings.Builder{}
+	names := map[Real]string{}
+	seen := map[Real]bool{}
+	var bind func(Real)
+	bind = func(c Real) {
+		if c == nil || seen[c] {
+			return
+		}
+
+		seen[c] = true
+		if _, ok := c.(*named); !ok {
+			for _, o := range operands(c) {
+				bind(o)
+			}
+		}
+		if refs[c] > 1 {
+			name := fmt.Sprintf("x%d", len(names))
+			sb.WriteString("let " + name + " = ")
+			prettyNode(sb, c, names)
+			sb.WriteString("\n")
+			names[c] = name
+		}
+	}
+	bind(c)
+
+	pretty(sb, c, names)
+	return sb.String()
+}
+
+// pretty writes c to sb, referring to any node in names by its name.
+func pretty(sb *strings.Builder, c Real, names map[Real]string) {
 	if c == nil {
 		sb.WriteString("nil")
 		return
 	}
+	if name, ok := names[c]; ok {
+		sb.WriteString(name)
+		return
+	}
 
+	prettyNode(sb, c, names)
+}
+
+func prettyNode(sb *strings.Builder, c Real, names map[Real]string) {
 	switch v := c.(type) {
 	case *named:
 		sb.WriteString(fmt.Sprintf("Named(%q)", v.Name))
@@ -24,19 +84,125 @@ func pretty(sb *strings.Builder, c Real) {
 		sb.WriteString(fmt.Sprintf("Integer(%s)", v.i))
 	case *constructiveMultiplication:
 		sb.WriteString("Multiply(")
-		pretty(sb, v.a)
+		pretty(sb, v.a, names)
 		sb.WriteString(", ")
-		pretty(sb, v.b)
+		pretty(sb, v.b, names)
 		sb.WriteString(")")
 	case *constructiveAddition:
-		pretty(sb, v.a)
+		pretty(sb, v.a, names)
 		sb.WriteString(" + ")
-		pretty(sb, v.b)
+		pretty(sb, v.b, names)
 	case *constructiveMultiplicativeInverse:
 		sb.WriteString("Inverse(")
-		pretty(sb, v.r)
+		pretty(sb, v.r, names)
+		sb.WriteString(")")
+	case *constructiveRational:
+		sb.WriteString(fmt.Sprintf("Rational(%s)", v.r.RatString()))
+	case *constructiveShift:
+		sb.WriteString("(")
+		pretty(sb, v.r, names)
+		if v.n < 0 {
+			sb.WriteString(fmt.Sprintf(" >> %d)", -v.n))
+		} else {
+			sb.WriteString(fmt.Sprintf(" << %d)", v.n))
+		}
+	case *constructiveNegation:
+		prettyCall(sb, "-", v.r, names)
+	case *constructiveCondsign:
+		sb.WriteString("(")
+		pretty(sb, v.r, names)
+		sb.WriteString(" < 0 ? ")
+		pretty(sb, v.a, names)
+		sb.WriteString(" : ")
+		pretty(sb, v.b, names)
 		sb.WriteString(")")
+	case *prescaledExponential:
+		prettyCall(sb, "e^", v.r, names)
+	case *prescaledNaturalLog:
+		prettyCall(sb, "ln", v.r, names)
+	case *prescaledSqrt:
+		prettyCall(sb, "√", v.r, names)
+	case *nthRoot:
+		prettyCall(sb, fmt.Sprintf("%d√", v.n), v.r, names)
+	case *prescaledCosine:
+		prettyCall(sb, "cos", v.r, names)
+	case *integralArctan:
+		sb.WriteString("arctan(1/")
+		pretty(sb, v.a, names)
+		sb.WriteString(")")
+	case *lambertW:
+		prettyCall(sb, "W", v.r, names)
+	case *powerSeries:
+		prettyCall(sb, "Σ", v.x, names)
+	case *zetaSeries:
+		sb.WriteString(fmt.Sprintf("ζ(%d)", v.s))
+	case *aperySeries:
+		sb.WriteString("ζ(3)")
+	case *chudnovskySeries:
+		sb.WriteString("Σ_Chudnovsky")
+	case *gaussLegendre:
+		sb.WriteString("π_GaussLegendre")
+	case *bisectionRoot:
+		v.mu.Lock()
+		a, b := v.a, v.b
+		v.mu.Unlock()
+
+		sb.WriteString("root[")
+		pretty(sb, a, names)
+		sb.WriteString(", ")
+		pretty(sb, b, names)
+		sb.WriteString("]")
 	default:
 		sb.WriteString(fmt.Sprintf("%T %+v", v, v))
 	}
 }
+
+// prettyCall writes c as the argument of a prefix operator or function.
+func prettyCall(sb *strings.Builder, op string, c Real, names map[Real]string) {
+	sb.WriteString(op)
+	sb.WriteString("(")
+	pretty(sb, c, names)
+	sb.WriteString(")")
+}
+
+// operands returns the Real numbers that c is directly constructed from.
+func operands(c Real) []Real {
+	switch v := c.(type) {
+	case *named:
+		return []Real{v.Real}
+	case *constructiveAddition:
+		return []Real{v.a, v.b}
+	case *constructiveMultiplication:
+		return []Real{v.a, v.b}
+	case *constructiveMultiplicativeInverse:
+		return []Real{v.r}
+	case *constructiveShift:
+		return []Real{v.r}
+	case *constructiveNegation:
+		return []Real{v.r}
+	case *constructiveCondsign:
+		return []Real{v.r, v.a, v.b}
+	case *prescaledExponential:
+		return []Real{v.r}
+	case *prescaledNaturalLog:
+		return []Real{v.r}
+	case *prescaledSqrt:
+		return []Real{v.r}
+	case *nthRoot:
+		return []Real{v.r}
+	case *prescaledCosine:
+		return []Real{v.r}
+	case *integralArctan:
+		return []Real{v.a}
+	case *lambertW:
+		return []Real{v.r}
+	case *powerSeries:
+		return []Real{v.x}
+	case *bisectionRoot:
+		v.mu.Lock()
+		defer v.mu.Unlock()
+		return []Real{v.a, v.b}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,95 @@
+package constructive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+var ErrRootNotBracketed = errors.New("root not bracketed")
+
+// bracketPrecision is the precision at which FindRoot decides the sign of the
+// function at the ends of the bracket.
+const bracketPrecision = -100
+
+// FindRoot finds a root of f between lo and hi, where f(lo) and f(hi) must
+// have opposite signs, or ErrRootNotBracketed is returned. The root is
+// returned as a Real number whose approximations are computed lazily by
+// bisecting the bracket until it is narrower than the requested precision.
+//
+// The bisection checks ctx on every step: when ctx is canceled, or when the
+// requested precision exceeds the precision limit of ctx, approximating the
+// root panics with the error, which Text reports as undefined.
+//
+// When the sign of f at a midpoint is indistinguishable from zero at a
+// precision well below the requested one, that midpoint is taken as the root,
+// which assumes f is not too flat near its root.
+func FindRoot(f func(Real) Real, lo, hi Real, ctx context.Context) (Real, error) {
+	slo := PreciseSign(f(lo), bracketPrecision)
+	shi := PreciseSign(f(hi), bracketPrecision)
+	if slo == 0 || shi == 0 || slo == shi {
+		return nil, ErrRootNotBracketed
+	}
+
+	return &bisectionRoot{
+		ctx: ctx,
+		f:   f,
+		a:   lo,
+		b:   hi,
+		sa:  slo,
+	}, nil
+}
+
+type bisectionRoot struct {
+	precisionTracker
+	ctx context.Context
+	f   func(Real) Real
+
+	// a and b bracket the root, with f(a) having sign sa; the bracket is
+	// narrowed in place, so later approximations resume where earlier ones
+	// stopped.
+	mu sync.Mutex
+	a  Real
+	b  Real
+	sa int
+}
+
+func (c *bisectionRoot) approximate(p int) *big.Int {
+	if err := CheckPrecisionOverflow(c.ctx, -p); err != nil {
+		panic(err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for bigAbs(Approximate(Subtract(c.b, c.a), p-3)).Cmp(big.NewInt(1)) > 0 {
+		if err := c.ctx.Err(); err != nil {
+			panic(err)
+		}
+
+		m := dyadic(Approximate(c.midpoint(), p-4), p-4)
+		switch PreciseSign(c.f(m), p-32) {
+		case 0:
+			c.a, c.b = m, m
+		case c.sa:
+			c.a = m
+		default:
+			c.b = m
+		}
+	}
+
+	return scale(Approximate(c.midpoint(), p-2), -2)
+}
+
+func (c *bisectionRoot) midpoint() Real {
+	return ShiftRight(Add(c.a, c.b), 1)
+}
+
+func (c *bisectionRoot) asConstruction() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return fmt.Sprintf("BisectionRoot(%s, %s)", c.a.asConstruction(), c.b.asConstruction())
+}
@@ -0,0 +1,134 @@
+package constructive
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Series computes the power series Σ coeff(n)·xⁿ, for n = 0, 1, 2, ...
+//
+// The truncation error is bounded automatically, which requires that
+// |x| <= 1/2 and that |coeff(n)| <= 1 for every n; the tail of the series
+// from n on is then at most 2|x|ⁿ, however sparse the coefficients are. It
+// returns nil when x is evidently larger than 1/2. Use SeriesWithTail for
+// series that converge quickly enough at larger x.
+func Series(coeff func(n int) *big.Rat, x Real) Real {
+	// |x| <= 1/2 implies that its approximation at 2^-4 is at most 9
+	if Approximate(x, -4).CmpAbs(big.NewInt(9)) > 0 {
+		return nil
+	}
+
+	return newPowerSeries(coeff, x, nil)
+}
+
+// SeriesWithTail computes the power series Σ coeff(n)·xⁿ like Series, but
+// for |x| <= 1, with the truncation error bounded by the caller: tail(n)
+// must bound Σ |coeff(k)| for k >= n, such as 2/n! for the coefficients 1/n!
+// of e^x. The terms are summed until tail(n) falls below the requested
+// precision, so tail must eventually do so. Like Series, it assumes that
+// |coeff(n)| <= 1 for every n.
+func SeriesWithTail(coeff func(n int) *big.Rat, x Real, tail func(n int) *big.Rat) Real {
+	return newPowerSeries(coeff, x, tail)
+}
+
+type powerSeries struct {
+	precisionTracker
+	coeff func(n int) *big.Rat
+	x     Real
+	tail  func(n int) *big.Rat
+}
+
+func newPowerSeries(coeff func(n int) *big.Rat, x Real, tail func(n int) *big.Rat) Real {
+	return &powerSeries{
+		coeff: coeff,
+		x:     x,
+		tail:  tail,
+	}
+}
+
+// terms computes the number of terms needed to approximate the series at
+// precision p. Without a tail, |x| < 9/16 bounds the tail from n on by
+// |x|ⁿ/(1 - 9/16) < 4|x|ⁿ, which is below 2^(p-4) once n >= 2(6-p).
+func (c *powerSeries) terms(p int) int {
+	if c.tail == nil {
+		return 2 * max(6-p, 0)
+	}
+
+	bound := new(big.Rat).SetInt(bigLsh(big.NewInt(1), uint(max(p-4, 0))))
+	if p < 4 {
+		bound.Inv(new(big.Rat).SetInt(bigLsh(big.NewInt(1), uint(4-p))))
+	}
+
+	n := 0
+	for c.tail(n).Cmp(bound) >= 0 {
+		n++
+	}
+	return n
+}
+
+func (c *powerSeries) approximate(p int) *big.Int {
+	// with |x| <= 1, each power of x carries at most 2n units of error, and so
+	// the sum of the first n terms carries at most 2n² units
+	iters := c.terms(p)
+	calcPrec := p - 2*boundLog2(iters+1) - 4
+	xAppr := Approximate(c.x, calcPrec)
+
+	xToTheN := bigLsh(big.NewInt(1), uint(-calcPrec))
+	sum := new(big.Int)
+	term := new(big.Int)
+	maxTruncError := bigLsh(big.NewInt(1), uint(p-4-calcPrec))
+	for n := 0; n < iters; n++ {
+		// the tail from n on is less than 4|x|ⁿ, which may already be small
+		if c.tail == nil && bigLsh(bigAbs(xToTheN), 2).Cmp(maxTruncError) < 0 {
+			break
+		}
+
+		r := c.coeff(n)
+		term.Mul(xToTheN, r.Num())
+		term.Quo(term, r.Denom())
+		sum.Add(sum, term)
+
+		scaleInto(xToTheN, xToTheN.Mul(xToTheN, xAppr), calcPrec)
+	}
+
+	return scale(sum, calcPrec-p)
+}
+
+func (c *powerSeries) asConstruction() string {
+	return fmt.Sprintf("Series(%s)", c.x.asConstruction())
+}
+
+// seriesPrecision computes the precision at which the terms of a series are
+// computed, in order to approximate its sum at precision p, allowing for the
+// rounding errors of up to iters terms.
+func seriesPrecision(p, iters int) int {
+	return p - boundLog2(2*iters) - 4
+}
+
+// sumSeries approximates the sum of a series at precision p. The terms are
+// scaled by 2^-calcPrec, starting with first; next replaces the current term
+// with the next one in place. Terms are added until one is smaller than the
+// maximum truncation error of 2^(p-4).
+func sumSeries(p, calcPrec int, first *big.Int, next func(term *big.Int)) *big.Int {
+	return sumSeriesWhile(p, calcPrec, first, next, func(int) bool { return true })
+}
+
+// sumPrescaledSeries approximates the sum of a prescaled series like
+// sumSeries, but also stops once maxIters is reached.
+func sumPrescaledSeries(p, calcPrec int, first *big.Int, next func(term *big.Int)) *big.Int {
+	return sumSeriesWhile(p, calcPrec, first, next, underMaxIters)
+}
+
+// sumSeriesWhile implements sumSeries, adding the next term only while more
+// reports true for the number of terms added beyond the first.
+func sumSeriesWhile(p, calcPrec int, first *big.Int, next func(term *big.Int), more func(k int) bool) *big.Int {
+	term := first
+	sum := new(big.Int).Set(first)
+	maxTruncError := bigLsh(big.NewInt(1), uint(p-4-calcPrec))
+	for k := 0; term.CmpAbs(maxTruncError) >= 0 && more(k); k++ {
+		next(term)
+		sum.Add(sum, term)
+	}
+
+	return scale(sum, calcPrec-p)
+}
@@ -0,0 +1,25 @@
+package constructive
+
+import "sync/atomic"
+
+// maxIters caps the number of terms that the prescaled series sum, when
+// positive. The series are otherwise summed until the truncation error is
+// within bounds. This is only a hook to verify, in tests, that the truncation
+// bounds are actually necessary; it must not be changed otherwise.
+var maxIters atomic.Int64
+
+// setMaxIters sets maxIters, returning a function that restores its previous
+// value.
+func setMaxIters(n int) (restore func()) {
+	old := maxIters.Swap(int64(n))
+	return func() {
+		maxIters.Store(old)
+	}
+}
+
+// underMaxIters checks whether a prescaled series that has summed k terms
+// beyond its first may continue.
+func underMaxIters(k int) bool {
+	limit := maxIters.Load()
+	return limit <= 0 || int64(k) < limit
+}
@@ -0,0 +1,267 @@
+package constructive
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var ErrInvalidSExpr = errors.New("invalid s-expression")
+
+// AsSExpr renders the construction of c as a Lisp-style s-expression, such
+// as `(multiply (int 4) (inverse (int 3)))`, which ParseSExpr parses back
+// into an equivalent construction. Nodes that cannot be parsed back, such as
+// those constructed from Go functions, are rendered as `(opaque "…")`.
+func AsSExpr(c Real) string {
+	sb := &strings.Builder{}
+	sexpr(sb, c)
+	return sb.String()
+}
+
+func sexpr(sb *strings.Builder, c Real) {
+	switch v := c.(type) {
+	case nil:
+		sb.WriteString("nil")
+	case *named:
+		fmt.Fprintf(sb, "(named %q ", v.Name)
+		sexpr(sb, v.Real)
+		sb.WriteString(")")
+	case *constructiveInteger:
+		fmt.Fprintf(sb, "(int %s)", v.i)
+	case *constructiveRational:
+		fmt.Fprintf(sb, "(rat %s)", v.r.RatString())
+	case *constructiveAddition:
+		sexprCall(sb, "add", v.a, v.b)
+	case *constructiveMultiplication:
+		sexprCall(sb, "multiply", v.a, v.b)
+	case *constructiveMultiplicativeInverse:
+		sexprCall(sb, "inverse", v.r)
+	case *constructiveShift:
+		sb.WriteString("(shift ")
+		sexpr(sb, v.r)
+		fmt.Fprintf(sb, " %d)", v.n)
+	case *constructiveNegation:
+		sexprCall(sb, "negate", v.r)
+	case *constructiveCondsign:
+		sexprCall(sb, "condsign", v.r, v.a, v.b)
+	case *prescaledExponential:
+		sexprCall(sb, "exp", v.r)
+	case *prescaledNaturalLog:
+		sexprCall(sb, "ln1p", v.r)
+	case *prescaledSqrt:
+		sexprCall(sb, "sqrt", v.r)
+	case *nthRoot:
+		sb.WriteString("(nth-root ")
+		sexpr(sb, v.r)
+		fmt.Fprintf(sb, " %d)", v.n)
+	case *prescaledCosine:
+		sexprCall(sb, "cos", v.r)
+	case *integralArctan:
+		sexprCall(sb, "arctan-inverse", v.a)
+	case *lambertW:
+		sexprCall(sb, "lambert-w", v.r)
+	case *zetaSeries:
+		fmt.Fprintf(sb, "(zeta %d)", v.s)
+	case *aperySeries:
+		sb.WriteString("(apery)")
+	case *chudnovskySeries:
+		sb.WriteString("(chudnovsky)")
+	case *gaussLegendre:
+		sb.WriteString("(gauss-legendre)")
+	default:
+		fmt.Fprintf(sb, "(opaque %q)", c.asConstruction())
+	}
+}
+
+// sexprCall writes the list of op followed by its operands to sb.
+func sexprCall(sb *strings.Builder, op string, cs ...Real) {
+	sb.WriteString("(" + op)
+	for _, c := range cs {
+		sb.WriteString(" ")
+		sexpr(sb, c)
+	}
+	sb.WriteString(")")
+}
+
+// sexprOperators maps the operators of s-expressions to the number of Real
+// operands they take and the constructor of the node they describe.
+var sexprOperators = map[string]struct {
+	arity int
+	build func(args []Real) Real
+}{
+	"add":            {2, func(args []Real) Real { return Add(args[0], args[1]) }},
+	"multiply":       {2, func(args []Real) Real { return Multiply(args[0], args[1]) }},
+	"inverse":        {1, func(args []Real) Real { return Inverse(args[0]) }},
+	"negate":         {1, func(args []Real) Real { return Negate(args[0]) }},
+	"condsign":       {3, func(args []Real) Real { return newCondsign(args[0], args[1], args[2]) }},
+	"exp":            {1, func(args []Real) Real { return newPrescaledExponential(args[0]) }},
+	"ln1p":           {1, func(args []Real) Real { return newPrescaledNaturalLog(args[0]) }},
+	"sqrt":           {1, func(args []Real) Real { return Sqrt(args[0]) }},
+	"cos":            {1, func(args []Real) Real { return newPrescaledCosine(args[0]) }},
+	"arctan-inverse": {1, func(args []Real) Real { return newIntegralArctan(args[0]) }},
+	"lambert-w":      {1, func(args []Real) Real { return newLambertW(args[0]) }},
+	"apery":          {0, func([]Real) Real { return newAperySeries() }},
+	"chudnovsky":     {0, func([]Real) Real { return newChudnovskySeries() }},
+	"gauss-legendre": {0, func([]Real) Real { return &gaussLegendre{} }},
+}
+
+// ParseSExpr parses an s-expression as rendered by AsSExpr into a Real
+// number, or returns an error wrapping ErrInvalidSExpr.
+func ParseSExpr(s string) (Real, error) {
+	p := &sexprParser{tokens: tokenizeSExpr(s)}
+	c, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("%w: unexpected %q after expression", ErrInvalidSExpr, p.tokens[p.pos])
+	}
+
+	return c, nil
+}
+
+// tokenizeSExpr splits s into parentheses, quoted strings, and atoms.
+func tokenizeSExpr(s string) []string {
+	var tokens []string
+	for i := 0; i < len(s); {
+		switch r := rune(s[i]); {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')':
+			tokens = append(tokens, s[i:i+1])
+			i++
+		case r == '"':
+			j := i + 1
+			for j < len(s) && s[j] != '"' {
+				if s[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			j = min(j+1, len(s))
+			tokens = append(tokens, s[i:j])
+			i = j
+		default:
+			j := i
+			for j < len(s) && !unicode.IsSpace(rune(s[j])) && s[j] != '(' && s[j] != ')' {
+				j++
+			}
+			tokens = append(tokens, s[i:j])
+			i = j
+		}
+	}
+
+	return tokens
+}
+
+type sexprParser struct {
+	tokens []string
+	pos    int
+}
+
+// next returns the next token, or the empty string at the end of input.
+func (p *sexprParser) next() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+
+	p.pos++
+	return p.tokens[p.pos-1]
+}
+
+// expect consumes the next token, which must be want.
+func (p *sexprParser) expect(want string) error {
+	if tok := p.next(); tok != want {
+		return fmt.Errorf("%w: expected %q, found %q", ErrInvalidSExpr, want, tok)
+	}
+	return nil
+}
+
+func (p *sexprParser) parse() (Real, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	var c Real
+	switch op := p.next(); op {
+	case "named":
+		name, err := strconv.Unquote(p.next())
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid name: %w", ErrInvalidSExpr, err)
+		}
+
+		r, err := p.parse()
+		if err != nil {
+			return nil, err
+		}
+		c = Named(name, r)
+	case "int":
+		tok := p.next()
+		i, ok := new(big.Int).SetString(tok, 10)
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid integer %q", ErrInvalidSExpr, tok)
+		}
+		c = FromBigInt(i)
+	case "rat":
+		tok := p.next()
+		r, ok := new(big.Rat).SetString(tok)
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid rational %q", ErrInvalidSExpr, tok)
+		}
+		c = FromRatExact(r)
+	case "shift":
+		r, err := p.parse()
+		if err != nil {
+			return nil, err
+		}
+
+		tok := p.next()
+		n, err := strconv.Atoi(tok)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid shift %q", ErrInvalidSExpr, tok)
+		}
+		c = newShift(r, n)
+	case "nth-root":
+		r, err := p.parse()
+		if err != nil {
+			return nil, err
+		}
+
+		tok := p.next()
+		n, err := strconv.Atoi(tok)
+		if err != nil || n < 3 {
+			return nil, fmt.Errorf("%w: invalid root %q", ErrInvalidSExpr, tok)
+		}
+		c = newNthRoot(r, n)
+	case "zeta":
+		tok := p.next()
+		n, err := strconv.Atoi(tok)
+		if err != nil || n < 2 {
+			return nil, fmt.Errorf("%w: invalid zeta argument %q", ErrInvalidSExpr, tok)
+		}
+		c = Zeta(n)
+	default:
+		o, ok := sexprOperators[op]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown operator %q", ErrInvalidSExpr, op)
+		}
+
+		args := make([]Real, o.arity)
+		for i := range args {
+			r, err := p.parse()
+			if err != nil {
+				return nil, err
+			}
+			args[i] = r
+		}
+		c = o.build(args)
+	}
+
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -0,0 +1,129 @@
+package constructive
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrEmpty             = errors.New("empty slice")
+	ErrIndistinguishable = errors.New("indistinguishable values")
+)
+
+// ArgMax returns the index of the largest of cs. Elements are compared at a
+// precision of 2^-100; if the largest element cannot be distinguished from
+// another, ErrIndistinguishable is returned, since either may be the largest.
+func ArgMax(cs []Real) (int, error) {
+	return argExtreme(cs, 1)
+}
+
+// ArgMin returns the index of the smallest of cs, like ArgMax.
+func ArgMin(cs []Real) (int, error) {
+	return argExtreme(cs, -1)
+}
+
+// argExtreme returns the index of the largest of cs when dir is 1, or the
+// smallest when dir is -1.
+func argExtreme(cs []Real, dir int) (int, error) {
+	if len(cs) == 0 {
+		return 0, ErrEmpty
+	}
+
+	best := 0
+	for i := 1; i < len(cs); i++ {
+		if PreciseCmp(cs[i], cs[best], zeroPrecision) == dir {
+			best = i
+		}
+	}
+
+	// an element may tie with the best without having tied with any of the
+	// elements that were best before it, so every element is checked again
+	for i := range cs {
+		if i != best && PreciseCmp(cs[i], cs[best], zeroPrecision) == 0 {
+			return 0, fmt.Errorf("%w: elements %d and %d", ErrIndistinguishable, best, i)
+		}
+	}
+	return best, nil
+}
+
+// StatsAccumulator accumulates a stream of Real numbers, so that their sum and
+// mean can be queried without holding on to every value. The values are kept
+// as partial sums of 1, 2, 4, ... values, like the digits of a binary counter,
+// so the sum stays a balanced tree, as with Sum. The zero value is ready to
+// use. It is not safe for concurrent use.
+type StatsAccumulator struct {
+	count int
+
+	// partials[k] is either nil or the sum of 2^k values
+	partials []Real
+}
+
+// Add adds c to the accumulator.
+func (s *StatsAccumulator) Add(c Real) {
+	s.count++
+
+	carry := c
+	for k := range s.partials {
+		if s.partials[k] == nil {
+			s.partials[k] = carry
+			return
+		}
+
+		carry = Add(s.partials[k], carry)
+		s.partials[k] = nil
+	}
+	s.partials = append(s.partials, carry)
+}
+
+// Count returns the number of values added.
+func (s *StatsAccumulator) Count() int {
+	return s.count
+}
+
+// Sum returns the sum of the values added, or zero if there are none.
+func (s *StatsAccumulator) Sum() Real {
+	var cs []Real
+	for _, p := range s.partials {
+		if p != nil {
+			cs = append(cs, p)
+		}
+	}
+	return Sum(cs...)
+}
+
+// Mean returns the arithmetic mean of the values added, or nil if there are
+// none.
+func (s *StatsAccumulator) Mean() Real {
+	if s.count == 0 {
+		return nil
+	}
+	return Divide(s.Sum(), FromInt(s.count))
+}
+
+// Variance computes the population variance Σ(cᵢ - μ)² / n of cs, where μ is
+// their mean, or nil if cs is empty. The mean is computed first, then the
+// squared deviations from it; since the arithmetic is exact, this does not
+// suffer the cancellation that motivates one-pass algorithms for floats.
+func Variance(cs []Real) Real {
+	if len(cs) == 0 {
+		return nil
+	}
+
+	mean := Divide(Sum(cs...), FromInt(len(cs)))
+	devs := make([]Real, len(cs))
+	for i, c := range cs {
+		devs[i] = Square(Subtract(c, mean))
+	}
+
+	return Divide(Sum(devs...), FromInt(len(cs)))
+}
+
+// StdDev computes the population standard deviation of cs, which is the
+// square root of their Variance, or nil if cs is empty.
+func StdDev(cs []Real) Real {
+	v := Variance(cs)
+	if v == nil {
+		return nil
+	}
+	return Sqrt(v)
+}
@@ -0,0 +1,21 @@
+package constructive
+
+// Tabulate evaluates f at n evenly spaced points, f(start), f(start+step),
+// f(start+2·step), and so on. Each point is accumulated from the previous one
+// with a single addition, rather than computing start + k·step afresh.
+func Tabulate(f func(Real) Real, start, step Real, n int) []Real {
+	if n <= 0 {
+		return nil
+	}
+
+	out := make([]Real, n)
+	x := start
+	for i := range out {
+		if i > 0 {
+			x = Add(x, step)
+		}
+		out[i] = f(x)
+	}
+
+	return out
+}
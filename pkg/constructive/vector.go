@@ -0,0 +1,34 @@
+package constructive
+
+// Dot computes the dot product Σ aᵢbᵢ of two vectors using a balanced sum.
+// It panics if the vectors have different lengths.
+func Dot(a, b []Real) Real {
+	if len(a) != len(b) {
+		panic("constructive: Dot of vectors with different lengths")
+	}
+
+	terms := make([]Real, len(a))
+	for i := range a {
+		terms[i] = Multiply(a[i], b[i])
+	}
+
+	return Sum(terms...)
+}
+
+// Norm computes the Euclidean norm √(Σ vᵢ²) of a vector.
+func Norm(v []Real) Real {
+	return Sqrt(Dot(v, v))
+}
+
+// Normalize scales every component of a vector by the inverse of its norm,
+// producing a unit vector in the same direction. The zero vector cannot be
+// normalized.
+func Normalize(v []Real) []Real {
+	inv := Inverse(Norm(v))
+
+	out := make([]Real, len(v))
+	for i, c := range v {
+		out[i] = Multiply(c, inv)
+	}
+	return out
+}
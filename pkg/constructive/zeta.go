@@ -0,0 +1,163 @@
+package constructive
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// Zeta computes the Riemann zeta function ζ(n) = Σ 1/kⁿ at integer n ≥ 2,
+// returning nil otherwise.
+//
+// Even arguments are computed exactly as a rational multiple of a power of π,
+//
+// ζ(2k) = |B₂ₖ| (2π)²ᵏ / (2 (2k)!)
+//
+// where B₂ₖ is a Bernoulli number; odd arguments are computed using Borwein's
+// accelerated series for the Dirichlet eta function.
+func Zeta(n int) Real {
+	if n < 2 {
+		return nil
+	}
+
+	if n%2 == 1 {
+		return newZetaSeries(n)
+	}
+
+	// |B_n| 2^n / (2 * n!)
+	coeff := new(big.Rat).Abs(bernoulli(n))
+	coeff.Mul(coeff, new(big.Rat).SetInt(bigLsh(big.NewInt(1), uint(n-1))))
+	coeff.Quo(coeff, new(big.Rat).SetInt(new(big.Int).MulRange(1, int64(n))))
+
+	return Multiply(Divide(FromBigInt(coeff.Num()), FromBigInt(coeff.Denom())), powInt(Pi(), n))
+}
+
+// powInt computes c^n for n ≥ 1 by repeated squaring.
+func powInt(c Real, n int) Real {
+	if n == 1 {
+		return c
+	}
+
+	h := Square(powInt(c, n/2))
+	if n%2 == 1 {
+		return Multiply(h, c)
+	}
+	return h
+}
+
+// bernoulli computes the Bernoulli number Bₙ using the Akiyama–Tanigawa
+// algorithm. This convention has B₁ = +1/2.
+func bernoulli(n int) *big.Rat {
+	a := make([]*big.Rat, n+1)
+	for m := 0; m <= n; m++ {
+		a[m] = big.NewRat(1, int64(m+1))
+		for j := m; j >= 1; j-- {
+			a[j-1].Sub(a[j-1], a[j])
+			a[j-1].Mul(a[j-1], big.NewRat(int64(j), 1))
+		}
+	}
+
+	return a[0]
+}
+
+type zetaSeries struct {
+	precisionTracker
+	s int
+}
+
+func newZetaSeries(s int) Real {
+	return &zetaSeries{
+		s: s,
+	}
+}
+
+// approximate computes ζ(s) using Borwein's algorithm:
+//
+// ζ(s) = -1/(dₙ (1 - 2^(1-s))) Σ_{k=0}^{n-1} (-1)^k (dₖ - dₙ)/(k+1)^s
+//
+// where dₖ = n Σ_{i=0}^{k} (n+i-1)! 4^i / ((n-i)! (2i)!), which has a
+// truncation error bounded by 3/(3+√8)^n / |1 - 2^(1-s)|.
+func (c *zetaSeries) approximate(p int) *big.Int {
+	if p >= 2 {
+		return big.NewInt(0)
+	}
+
+	n := int(float64(-p+3)/math.Log2(3+math.Sqrt(8))) + 2
+	calcPrec := p - boundLog2(n) - 4
+
+	// dₖ are integers; tᵢ is the i-th summand of dₙ
+	d := make([]*big.Int, n+1)
+	t := big.NewInt(1)
+	d[0] = big.NewInt(1)
+	for i := 0; i < n; i++ {
+		t = bigMul(t, big.NewInt(int64(4*(n+i)*(n-i))))
+		t = bigDiv(t, big.NewInt(int64((2*i+1)*(2*i+2))))
+		d[i+1] = bigAdd(d[i], t)
+	}
+
+	one := bigLsh(big.NewInt(1), uint(-calcPrec))
+	sum := big.NewInt(0)
+	for k := 0; k < n; k++ {
+		ks := bigExp(big.NewInt(int64(k+1)), big.NewInt(int64(c.s)), nil)
+		term := bigDiv(bigMul(one, bigSub(d[k], d[n])), ks)
+		if k%2 == 1 {
+			term = bigNeg(term)
+		}
+		sum = bigAdd(sum, term)
+	}
+
+	// -1/(1 - 2^(1-s)) = -2^(s-1)/(2^(s-1) - 1)
+	half := bigLsh(big.NewInt(1), uint(c.s-1))
+	num := bigNeg(bigMul(sum, half))
+	den := bigMul(d[n], bigSub(half, big.NewInt(1)))
+
+	return scale(bigDiv(num, den), calcPrec-p)
+}
+
+func (c *zetaSeries) asConstruction() string {
+	return fmt.Sprintf("Zeta(%d)", c.s)
+}
+
+type aperySeries struct {
+	precisionTracker
+}
+
+func newAperySeries() Real {
+	return &aperySeries{}
+}
+
+// approximate computes Apéry's constant using the series:
+//
+// ζ(3) = 5/2 Σ_{k=1}^∞ (-1)^(k+1) / (k³ C(2k, k))
+//
+// whose terms shrink by roughly a factor of 4 each.
+func (c *aperySeries) approximate(p int) *big.Int {
+	if p >= 2 {
+		return big.NewInt(0)
+	}
+
+	iters := -p/2 + 2
+	calcPrec := p - boundLog2(2*iters) - 4
+	one := bigLsh(big.NewInt(1), uint(-calcPrec))
+
+	binom := big.NewInt(1)
+	sum := big.NewInt(0)
+	for k := int64(1); ; k++ {
+		// C(2k, k) = C(2k-2, k-1) * (2k)(2k-1) / k²
+		binom = bigDiv(bigMul(binom, big.NewInt(2*k*(2*k-1))), big.NewInt(k*k))
+		term := bigDiv(one, bigMul(binom, big.NewInt(k*k*k)))
+		if term.Sign() == 0 {
+			break
+		}
+		if k%2 == 0 {
+			term = bigNeg(term)
+		}
+		sum = bigAdd(sum, term)
+	}
+
+	return scale(bigMul(sum, big.NewInt(5)), calcPrec-p-1)
+}
+
+func (c *aperySeries) asConstruction() string {
+	return "AperySeries()"
+}
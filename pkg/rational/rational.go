@@ -2,6 +2,7 @@ package rational
 
 import (
 	"math/big"
+	"sync"
 
 	"github.com/ripta/reals/pkg/constructive"
 )
@@ -9,6 +10,11 @@ import (
 // Number represents a rational number.
 type Number struct {
 	r *big.Rat
+
+	// cr lazily caches the constructive representation, so that repeated
+	// conversions share one precision cache.
+	mu sync.Mutex
+	cr constructive.Real
 }
 
 // New creates a new rational number from int numerator and denominator.
@@ -32,9 +38,17 @@ func FromRational(r *big.Rat) *Number {
 	}
 }
 
-// Constructive converts the rational number to a constructive real.
+// Constructive converts the rational number to a constructive real. The
+// result is an exact rational node, which is created once and reused by
+// subsequent calls.
 func (r *Number) Constructive() constructive.Real {
-	return constructive.Divide(constructive.FromBigInt(r.r.Num()), constructive.FromBigInt(r.r.Denom()))
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cr == nil {
+		r.cr = constructive.FromRatExact(r.r)
+	}
+	return r.cr
 }
 
 // Add adds two rational numbers.
@@ -58,8 +72,12 @@ func (r *Number) Multiply(other *Number) *Number {
 	}
 }
 
-// Divide divides two rational numbers.
+// Divide divides two rational numbers. Like Inverse, it returns nil when
+// other is zero.
 func (r *Number) Divide(other *Number) *Number {
+	if other.IsZero() {
+		return nil
+	}
 	return &Number{
 		r: new(big.Rat).Quo(r.r, other.r),
 	}
@@ -103,6 +121,40 @@ func (r *Number) Negate() *Number {
 	}
 }
 
+// Abs returns the absolute value of the rational number.
+func (r *Number) Abs() *Number {
+	return &Number{
+		r: new(big.Rat).Abs(r.r),
+	}
+}
+
+// RoundTo returns the integer multiple of multiple nearest to the rational
+// number, rounding halfway cases to the even multiple, or nil when multiple
+// is zero.
+func (r *Number) RoundTo(multiple *Number) *Number {
+	if multiple.IsZero() {
+		return nil
+	}
+
+	m := new(big.Rat).Abs(multiple.r)
+	q := new(big.Rat).Quo(r.r, m)
+
+	// q = k + rem/denom, with 0 <= rem < denom
+	k, rem := new(big.Int).DivMod(q.Num(), q.Denom(), new(big.Int))
+	switch rem.Lsh(rem, 1).Cmp(q.Denom()) {
+	case 1:
+		k.Add(k, big.NewInt(1))
+	case 0:
+		if k.Bit(0) == 1 {
+			k.Add(k, big.NewInt(1))
+		}
+	}
+
+	return &Number{
+		r: m.Mul(m, new(big.Rat).SetInt(k)),
+	}
+}
+
 // Inverse returns the multiplicative inverse of the rational number.
 func (r *Number) Inverse() *Number {
 	if r.r.Num().Sign() == 0 {
@@ -129,6 +181,98 @@ func (r *Number) Cmp(other *Number) int {
 	return r.r.Cmp(other.r)
 }
 
+// CmpAbs compares the magnitudes of two rational numbers: -1 if |r| < |other|,
+// 0 if |r| == |other|, 1 if |r| > |other|.
+func (r *Number) CmpAbs(other *Number) int {
+	return new(big.Rat).Abs(r.r).Cmp(new(big.Rat).Abs(other.r))
+}
+
+// CmpInt64 compares the rational number to an integer: -1 if r < n, 0 if
+// r == n, 1 if r > n.
+func (r *Number) CmpInt64(n int64) int {
+	return r.r.Cmp(new(big.Rat).SetInt64(n))
+}
+
+// CmpReal compares the rational number to a constructive real c at precision
+// p, using constructive.PreciseCmp: -1 if r < c, 1 if r > c, and 0 if they
+// cannot be distinguished at that precision.
+func (r *Number) CmpReal(c constructive.Real, p int) int {
+	return constructive.PreciseCmp(r.Constructive(), c, p)
+}
+
+// CmpRat compares a constructive real c to the rational r at precision p:
+// -1 if c < r, 1 if c > r, and 0 if they cannot be distinguished at that
+// precision. Since r is exact, only c is approximated, so c is distinguished
+// from r whenever they differ by at least 2^(p+1).
+func CmpRat(c constructive.Real, r *Number, p int) int {
+	appr := constructive.Approximate(c, p)
+	if appr == nil {
+		return 0
+	}
+
+	// |appr - c·2^-p| < 1, so compare r·2^-p against appr ± 1
+	x := r.ShiftRight(p).r
+	if new(big.Rat).SetInt(new(big.Int).Sub(appr, big.NewInt(1))).Cmp(x) >= 0 {
+		return 1
+	}
+	if new(big.Rat).SetInt(new(big.Int).Add(appr, big.NewInt(1))).Cmp(x) <= 0 {
+		return -1
+	}
+	return 0
+}
+
+// RationalWithin returns a rational number whose distance to c is less than
+// maxError, or nil if maxError is not positive. The result is an
+// approximation of c at the coarsest power of two below maxError, so unlike
+// a best rational approximation, it bounds the error rather than the
+// denominator.
+func RationalWithin(c constructive.Real, maxError *Number) *Number {
+	if maxError.Sign() <= 0 {
+		return nil
+	}
+
+	// 2^p < maxError, since maxError > 2^(len(num) - 1 - len(denom))
+	p := maxError.r.Num().BitLen() - maxError.r.Denom().BitLen() - 2
+	appr := constructive.Approximate(c, p)
+	if appr == nil {
+		return nil
+	}
+
+	return New(appr, big.NewInt(1)).ShiftLeft(p)
+}
+
+// GCD computes the greatest common divisor of a and b: the largest rational g
+// such that a/g and b/g are both integers, which is the greatest common
+// divisor of the numerators over the least common multiple of the
+// denominators. The result is never negative, and GCD(0, 0) is 0.
+func GCD(a, b *Number) *Number {
+	num := new(big.Int).GCD(nil, nil, bigAbs(a.r.Num()), bigAbs(b.r.Num()))
+	return New(num, lcm(a.r.Denom(), b.r.Denom()))
+}
+
+// LCM computes the least common multiple of a and b: the smallest positive
+// rational that is an integer multiple of both, which is the least common
+// multiple of the numerators over the greatest common divisor of the
+// denominators. If either a or b is zero, the result is 0.
+func LCM(a, b *Number) *Number {
+	denom := new(big.Int).GCD(nil, nil, a.r.Denom(), b.r.Denom())
+	return New(lcm(bigAbs(a.r.Num()), bigAbs(b.r.Num())), denom)
+}
+
+// lcm computes the least common multiple of the non-negative integers a and b.
+func lcm(a, b *big.Int) *big.Int {
+	if a.Sign() == 0 || b.Sign() == 0 {
+		return new(big.Int)
+	}
+
+	g := new(big.Int).GCD(nil, nil, a, b)
+	return g.Mul(new(big.Int).Quo(a, g), b)
+}
+
+func bigAbs(i *big.Int) *big.Int {
+	return new(big.Int).Abs(i)
+}
+
 // String returns the string representation of the rational number. If the
 // denominator is 1, it returns just the numerator. Otherwise, it returns
 // "numerator/denominator".
@@ -0,0 +1,29 @@
+package rational
+
+import "math/big"
+
+// Convergents computes the sequence of convergents p_k/q_k of the simple
+// continued fraction [a₀; a₁, a₂, ...] given by fracs, using the recurrence:
+//
+// p_k = a_k * p_{k-1} + p_{k-2}
+// q_k = a_k * q_{k-1} + q_{k-2}
+//
+// with p_{-1} = 1, q_{-1} = 0, p_{-2} = 0, q_{-2} = 1. Each convergent is the
+// best rational approximation of the continued fraction for its denominator.
+func Convergents(fracs []int64) []*Number {
+	convs := make([]*Number, 0, len(fracs))
+
+	p1, p2 := big.NewInt(1), big.NewInt(0)
+	q1, q2 := big.NewInt(0), big.NewInt(1)
+	for _, frac := range fracs {
+		a := big.NewInt(frac)
+		p := new(big.Int).Add(new(big.Int).Mul(a, p1), p2)
+		q := new(big.Int).Add(new(big.Int).Mul(a, q1), q2)
+
+		convs = append(convs, New(p, q))
+		p1, p2 = p, p1
+		q1, q2 = q, q1
+	}
+
+	return convs
+}
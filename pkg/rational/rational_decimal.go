@@ -0,0 +1,127 @@
+package rational
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+var ErrInvalidDecimal = errors.New("invalid decimal")
+
+// DecimalExpansion computes the exact, eventually-periodic expansion of r in
+// the given radix (between 2 and 36) using long division, detecting the cycle
+// of remainders. It returns the integer part (with a leading "-" when r is
+// negative), the non-repeating fractional digits, and the repeating fractional
+// digits, which are empty when the expansion terminates.
+//
+// For example, 1/6 in radix 10 is 0.1666..., which is returned as "0", "1",
+// and "6".
+func DecimalExpansion(r *Number, radix int) (intPart string, nonRepeat string, repeat string) {
+	num := new(big.Int).Abs(r.r.Num())
+	denom := r.r.Denom()
+
+	q, rem := new(big.Int).QuoRem(num, denom, new(big.Int))
+	intPart = q.Text(radix)
+	if r.r.Sign() < 0 {
+		intPart = "-" + intPart
+	}
+
+	base := big.NewInt(int64(radix))
+	seen := map[string]int{}
+	frac := strings.Builder{}
+	for rem.Sign() != 0 {
+		key := rem.String()
+		if idx, ok := seen[key]; ok {
+			fs := frac.String()
+			return intPart, fs[:idx], fs[idx:]
+		}
+		seen[key] = frac.Len()
+
+		rem.Mul(rem, base)
+		q.QuoRem(rem, denom, rem)
+		frac.WriteByte(digits[q.Int64()])
+	}
+
+	return intPart, frac.String(), ""
+}
+
+// Text returns the exact representation of the rational number in the given
+// radix, with any repeating group of digits enclosed in parentheses, e.g.,
+// "0.1(6)" for 1/6 and "0.(142857)" for 1/7 in radix 10. Terminating
+// expansions have no parentheses, and integers have no radix point.
+func (r *Number) Text(radix int) string {
+	intPart, nonRepeat, repeat := DecimalExpansion(r, radix)
+	if nonRepeat == "" && repeat == "" {
+		return intPart
+	}
+	if repeat == "" {
+		return intPart + "." + nonRepeat
+	}
+
+	return intPart + "." + nonRepeat + "(" + repeat + ")"
+}
+
+// ParseDecimal parses a base-10 decimal string into its exact rational value.
+// The string may have a leading sign, and its fractional part may end with a
+// parenthesized repeating group, e.g., "0.25" is 1/4, "-3.14" is -157/50, and
+// "0.1(6)" is 1/6. It is the inverse of Text in radix 10.
+func ParseDecimal(s string) (*Number, error) {
+	str := s
+	neg := false
+	if len(str) > 0 && (str[0] == '-' || str[0] == '+') {
+		neg = str[0] == '-'
+		str = str[1:]
+	}
+
+	intPart, fracPart, hasPoint := strings.Cut(str, ".")
+	nonRepeat, repeat := fracPart, ""
+	if idx := strings.IndexByte(fracPart, '('); idx >= 0 {
+		if !strings.HasSuffix(fracPart, ")") {
+			return nil, fmt.Errorf("%w: unterminated repeating group in %q", ErrInvalidDecimal, s)
+		}
+		nonRepeat, repeat = fracPart[:idx], fracPart[idx+1:len(fracPart)-1]
+		if repeat == "" {
+			return nil, fmt.Errorf("%w: empty repeating group in %q", ErrInvalidDecimal, s)
+		}
+	}
+
+	if intPart == "" && nonRepeat == "" && repeat == "" {
+		return nil, fmt.Errorf("%w: no digits in %q", ErrInvalidDecimal, s)
+	}
+	if hasPoint && nonRepeat == "" && repeat == "" {
+		return nil, fmt.Errorf("%w: no digits after decimal point in %q", ErrInvalidDecimal, s)
+	}
+	for _, part := range []string{intPart, nonRepeat, repeat} {
+		if strings.Trim(part, "0123456789") != "" {
+			return nil, fmt.Errorf("%w: unexpected character in %q", ErrInvalidDecimal, s)
+		}
+	}
+
+	// I.N(R) = (IN + R/(10^r - 1)) / 10^n, where n and r are the number of
+	// digits in N and R respectively
+	ten := big.NewInt(10)
+	r := new(big.Rat).SetInt(parseDigits(intPart + nonRepeat))
+	if repeat != "" {
+		rd := new(big.Int).Exp(ten, big.NewInt(int64(len(repeat))), nil)
+		rd.Sub(rd, big.NewInt(1))
+		r.Add(r, new(big.Rat).SetFrac(parseDigits(repeat), rd))
+	}
+	r.Quo(r, new(big.Rat).SetInt(new(big.Int).Exp(ten, big.NewInt(int64(len(nonRepeat))), nil)))
+
+	if neg {
+		r.Neg(r)
+	}
+	return &Number{r: r}, nil
+}
+
+// parseDigits parses a possibly-empty string of decimal digits.
+func parseDigits(s string) *big.Int {
+	i, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return new(big.Int)
+	}
+	return i
+}
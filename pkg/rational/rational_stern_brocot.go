@@ -0,0 +1,40 @@
+package rational
+
+import "math/big"
+
+// Mediant computes the mediant of two rational numbers a = n₁/d₁ and
+// b = n₂/d₂, which is (n₁+n₂)/(d₁+d₂) using their reduced forms. The mediant
+// of two distinct rationals lies strictly between them.
+func Mediant(a, b *Number) *Number {
+	num := new(big.Int).Add(a.r.Num(), b.r.Num())
+	denom := new(big.Int).Add(a.r.Denom(), b.r.Denom())
+	return New(num, denom)
+}
+
+// SternBrocotPath computes the path from the root 1/1 of the Stern–Brocot
+// tree to the positive rational r, as a sequence of 'L' (toward the left,
+// smaller child) and 'R' (toward the right, larger child) moves. The path to
+// 1/1 is empty. For example, the path to 2/3 is "LR" and the path to 3/4 is
+// "LRR". It returns nil if r is not positive.
+func SternBrocotPath(r *Number) []byte {
+	if r.Sign() <= 0 {
+		return nil
+	}
+
+	num := new(big.Int).Set(r.r.Num())
+	denom := new(big.Int).Set(r.r.Denom())
+
+	path := []byte{}
+	for {
+		switch num.Cmp(denom) {
+		case 0:
+			return path
+		case 1:
+			path = append(path, 'R')
+			num.Sub(num, denom)
+		default:
+			path = append(path, 'L')
+			denom.Sub(denom, num)
+		}
+	}
+}
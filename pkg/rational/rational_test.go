@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/ripta/reals/pkg/constructive"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestNew(t *testing.T) {
@@ -25,6 +26,15 @@ func TestNumber(t *testing.T) {
 	assertRationalEqual(t, New64(-3, 8), New64(3, 4).Multiply(New64(-1, 2)))
 	assertRationalEqual(t, New64(-3, 8), New64(3, 8).Negate())
 	assertRationalEqual(t, New64(3, 8), New64(8, 3).Inverse())
+	assertRationalEqual(t, New64(3, 2), New64(3, 4).Divide(New64(1, 2)))
+}
+
+func TestNumber_ByZero(t *testing.T) {
+	assert.NotPanics(t, func() {
+		assert.Nil(t, One().Divide(Zero()))
+		assert.Nil(t, Zero().Divide(Zero()))
+		assert.Nil(t, Zero().Inverse())
+	})
 }
 
 func assertRationalEqual(t *testing.T, expected, actual *Number) {
@@ -38,6 +48,12 @@ func TestNumber_Constructive(t *testing.T) {
 	assertEqualAtPrecision(t, constructive.Pi(), New64(22, 7).Constructive(), -9)
 	assertEqualAtPrecision(t, constructive.Pi(), New64(223, 71).Constructive(), -9)
 	assertEqualAtPrecision(t, constructive.Pi(), New64(377, 120).Constructive(), -13)
+
+	// the constructive representation is exact and shared across calls
+	r := New64(1, 3)
+	assert.Same(t, r.Constructive(), r.Constructive())
+	assert.Equal(t, "Rat(1/3)", constructive.AsConstruction(r.Constructive()))
+	assert.True(t, constructive.IsRational(r.Constructive()))
 }
 
 func assertEqualAtPrecision(t *testing.T, a, b constructive.Real, precision int) {
@@ -85,3 +101,241 @@ func TestShift(t *testing.T) {
 	assertRationalEqual(t, New64(3072, 4), New64(3, 4).ShiftLeft(10))  // 3/4 * 1024 = 3072/4
 	assertRationalEqual(t, New64(3, 4096), New64(3, 4).ShiftRight(10)) // 3/4 / 1024 = 3/4096
 }
+
+func TestConvergents(t *testing.T) {
+	// π = [3; 7, 15, 1, ...]
+	convs := Convergents([]int64{3, 7, 15, 1})
+	expected := []*Number{New64(3, 1), New64(22, 7), New64(333, 106), New64(355, 113)}
+	if len(convs) != len(expected) {
+		t.Fatalf("expected %d convergents, got %d", len(expected), len(convs))
+	}
+	for i := range expected {
+		assertRationalEqual(t, expected[i], convs[i])
+	}
+
+	// 47/17 = [2; 1, 3, 4]
+	convs = Convergents([]int64{2, 1, 3, 4})
+	assertRationalEqual(t, New64(47, 17), convs[len(convs)-1])
+
+	if convs := Convergents(nil); len(convs) != 0 {
+		t.Errorf("expected no convergents, got %v", convs)
+	}
+}
+
+type decimalExpansionTest struct {
+	input     *Number
+	radix     int
+	intPart   string
+	nonRepeat string
+	repeat    string
+}
+
+var decimalExpansionTests = []decimalExpansionTest{
+	{New64(1, 6), 10, "0", "1", "6"},
+	{New64(1, 7), 10, "0", "", "142857"},
+	{New64(1, 4), 10, "0", "25", ""},
+	{New64(1, 12), 10, "0", "08", "3"},
+	{New64(22, 7), 10, "3", "", "142857"},
+	{New64(-1, 6), 10, "-0", "1", "6"},
+	{New64(5, 1), 10, "5", "", ""},
+	{Zero(), 10, "0", "", ""},
+	{New64(1, 3), 2, "0", "", "01"},
+	{New64(1, 3), 3, "0", "1", ""},
+	{New64(1, 10), 16, "0", "1", "9"},
+}
+
+func TestDecimalExpansion(t *testing.T) {
+	for _, test := range decimalExpansionTests {
+		intPart, nonRepeat, repeat := DecimalExpansion(test.input, test.radix)
+		if intPart != test.intPart || nonRepeat != test.nonRepeat || repeat != test.repeat {
+			t.Errorf("expansion of %s in radix %d: expected (%q, %q, %q), got (%q, %q, %q)", test.input, test.radix, test.intPart, test.nonRepeat, test.repeat, intPart, nonRepeat, repeat)
+		}
+	}
+}
+
+func TestText(t *testing.T) {
+	assert.Equal(t, "0.1(6)", New64(1, 6).Text(10))
+	assert.Equal(t, "0.(142857)", New64(1, 7).Text(10))
+	assert.Equal(t, "0.08(3)", New64(1, 12).Text(10))
+	assert.Equal(t, "0.25", New64(1, 4).Text(10))
+	assert.Equal(t, "-3.125", New64(-25, 8).Text(10))
+	assert.Equal(t, "5", New64(5, 1).Text(10))
+	assert.Equal(t, "0", Zero().Text(10))
+	assert.Equal(t, "-0.(3)", New64(-1, 3).Text(10))
+	assert.Equal(t, "0.(01)", New64(1, 3).Text(2))
+	assert.Equal(t, "0.1(9)", New64(1, 10).Text(16))
+}
+
+func TestMediant(t *testing.T) {
+	assertRationalEqual(t, New64(1, 2), Mediant(New64(0, 1), New64(1, 1)))
+	assertRationalEqual(t, New64(2, 3), Mediant(New64(1, 2), New64(1, 1)))
+	assertRationalEqual(t, New64(2, 5), Mediant(New64(1, 3), New64(1, 2)))
+	// reduced forms are used: 2/4 is 1/2
+	assertRationalEqual(t, New64(2, 3), Mediant(New64(2, 4), New64(1, 1)))
+}
+
+func TestSternBrocotPath(t *testing.T) {
+	assert.Equal(t, "", string(SternBrocotPath(One())))
+	assert.Equal(t, "L", string(SternBrocotPath(New64(1, 2))))
+	assert.Equal(t, "R", string(SternBrocotPath(New64(2, 1))))
+	assert.Equal(t, "LR", string(SternBrocotPath(New64(2, 3))))
+	assert.Equal(t, "LRR", string(SternBrocotPath(New64(3, 4))))
+	assert.Equal(t, "RRLRRRLLL", string(SternBrocotPath(New64(47, 17))))
+	assert.Nil(t, SternBrocotPath(Zero()))
+	assert.Nil(t, SternBrocotPath(New64(-1, 2)))
+}
+
+func TestAbs(t *testing.T) {
+	assertRationalEqual(t, New64(3, 4), New64(-3, 4).Abs())
+	assertRationalEqual(t, New64(3, 4), New64(3, 4).Abs())
+	assertRationalEqual(t, Zero(), Zero().Abs())
+
+	// the receiver is not modified
+	r := New64(-1, 2)
+	r.Abs()
+	assertRationalEqual(t, New64(-1, 2), r)
+}
+
+func TestCmpAbs(t *testing.T) {
+	assert.Equal(t, 1, New64(-5, 1).CmpAbs(New64(3, 1)))
+	assert.Equal(t, -1, New64(1, 2).CmpAbs(New64(-2, 3)))
+	assert.Equal(t, 0, New64(-7, 3).CmpAbs(New64(7, 3)))
+	assert.Equal(t, 0, Zero().CmpAbs(Zero()))
+}
+
+func TestCmpInt64(t *testing.T) {
+	assert.Equal(t, 1, New64(7, 2).CmpInt64(3))
+	assert.Equal(t, -1, New64(7, 2).CmpInt64(4))
+	assert.Equal(t, 0, New64(8, 2).CmpInt64(4))
+	assert.Equal(t, -1, New64(-1, 2).CmpInt64(0))
+}
+
+func TestCmpReal(t *testing.T) {
+	// 22/7 - π ≈ 2^-9.6, which is indistinguishable at precision -9
+	assert.Equal(t, 0, New64(22, 7).CmpReal(constructive.Pi(), -9))
+	assert.Equal(t, 1, New64(22, 7).CmpReal(constructive.Pi(), -12))
+	assert.Equal(t, -1, New64(3, 1).CmpReal(constructive.Pi(), -9))
+	assert.Equal(t, 0, New64(1, 2).CmpReal(constructive.FromRat(1, 2), -100))
+}
+
+func TestCmpRat(t *testing.T) {
+	assert.Equal(t, -1, CmpRat(constructive.Pi(), New64(22, 7), -30))
+	assert.Equal(t, 1, CmpRat(constructive.Pi(), New64(3, 1), -30))
+
+	// 22/7 - π ≈ 2^-9.6, so it is guaranteed to be distinguished at -11, one
+	// bit coarser than PreciseCmp needs, but not at -8
+	assert.Equal(t, -1, CmpRat(constructive.Pi(), New64(22, 7), -11))
+	assert.Equal(t, 0, CmpRat(constructive.Pi(), New64(22, 7), -8))
+
+	assert.Equal(t, 0, CmpRat(constructive.FromRat(1, 3), New64(1, 3), -100))
+	assert.Equal(t, -1, CmpRat(constructive.FromRat(-1, 3), New64(-1, 4), -10))
+	assert.Equal(t, 1, CmpRat(constructive.FromInt(1000), New64(999, 1), 0))
+}
+
+func TestRationalWithin(t *testing.T) {
+	for _, maxError := range []*Number{New64(1, 1000), New64(1, 1), New64(3, 1), New64(1, 1024), New64(7, 100000000000)} {
+		for _, c := range []constructive.Real{constructive.Pi(), constructive.Negate(constructive.E()), constructive.FromRat(1, 3)} {
+			r := RationalWithin(c, maxError)
+			assert.Equal(t, 1, CmpRat(c, r.Subtract(maxError), -60), "%s within %s", r, maxError)
+			assert.Equal(t, -1, CmpRat(c, r.Add(maxError), -60), "%s within %s", r, maxError)
+		}
+	}
+
+	assert.Nil(t, RationalWithin(constructive.Pi(), Zero()))
+	assert.Nil(t, RationalWithin(constructive.Pi(), New64(-1, 10)))
+}
+
+type roundToTest struct {
+	r        *Number
+	multiple *Number
+	expected *Number
+}
+
+var roundToTests = []roundToTest{
+	{New64(7, 10), New64(1, 4), New64(3, 4)},
+	{New64(1, 8), New64(1, 4), New64(0, 1)},
+	{New64(3, 8), New64(1, 4), New64(1, 2)},
+	{New64(5, 2), New64(1, 1), New64(2, 1)},
+	{New64(7, 2), New64(1, 1), New64(4, 1)},
+	{New64(-5, 2), New64(1, 1), New64(-2, 1)},
+	{New64(-7, 10), New64(1, 4), New64(-3, 4)},
+	{New64(-7, 10), New64(-1, 4), New64(-3, 4)},
+	{New64(22, 7), New64(1, 100), New64(157, 50)},
+	{New64(3, 1), New64(5, 1), New64(5, 1)},
+	{New64(0, 1), New64(1, 3), New64(0, 1)},
+}
+
+func TestRoundTo(t *testing.T) {
+	for _, test := range roundToTests {
+		assert.Equal(t, test.expected.String(), test.r.RoundTo(test.multiple).String(), "%s to %s", test.r, test.multiple)
+	}
+
+	assert.Nil(t, New64(1, 2).RoundTo(Zero()))
+}
+
+type gcdTest struct {
+	a   *Number
+	b   *Number
+	gcd *Number
+	lcm *Number
+}
+
+var gcdTests = []gcdTest{
+	{New64(1, 2), New64(1, 3), New64(1, 6), New64(1, 1)},
+	{New64(3, 2), New64(9, 4), New64(3, 4), New64(9, 2)},
+	{New64(12, 1), New64(18, 1), New64(6, 1), New64(36, 1)},
+	{New64(-4, 3), New64(2, 9), New64(2, 9), New64(4, 3)},
+	{New64(0, 1), New64(5, 7), New64(5, 7), New64(0, 1)},
+	{New64(0, 1), New64(0, 1), New64(0, 1), New64(0, 1)},
+}
+
+func TestGCD(t *testing.T) {
+	for _, test := range gcdTests {
+		assert.Equal(t, test.gcd.String(), GCD(test.a, test.b).String(), "GCD(%s, %s)", test.a, test.b)
+		assert.Equal(t, test.gcd.String(), GCD(test.b, test.a).String(), "GCD(%s, %s)", test.b, test.a)
+		assert.Equal(t, test.lcm.String(), LCM(test.a, test.b).String(), "LCM(%s, %s)", test.a, test.b)
+		assert.Equal(t, test.lcm.String(), LCM(test.b, test.a).String(), "LCM(%s, %s)", test.b, test.a)
+	}
+}
+
+type parseDecimalTest struct {
+	input    string
+	expected *Number
+}
+
+var parseDecimalTests = []parseDecimalTest{
+	{"0.(142857)", New64(1, 7)},
+	{"3.14", New64(157, 50)},
+	{"0.25", New64(1, 4)},
+	{"0.1(6)", New64(1, 6)},
+	{"0.08(3)", New64(1, 12)},
+	{"-3.125", New64(-25, 8)},
+	{"+2", New64(2, 1)},
+	{"42", New64(42, 1)},
+	{".5", New64(1, 2)},
+	{"0.(9)", One()},
+	{"1.2(34)", New64(611, 495)},
+}
+
+func TestParseDecimal(t *testing.T) {
+	for _, test := range parseDecimalTests {
+		actual, err := ParseDecimal(test.input)
+		if assert.NoError(t, err, test.input) {
+			assertRationalEqual(t, test.expected, actual)
+		}
+	}
+
+	for _, input := range []string{"", "-", ".", "1.", "abc", "1.2.3", "0.(", "0.()", "0.(1", "1e5", "0.(1)2"} {
+		_, err := ParseDecimal(input)
+		assert.ErrorIs(t, err, ErrInvalidDecimal, input)
+	}
+}
+
+func TestParseDecimal_RoundTrip(t *testing.T) {
+	for _, r := range []*Number{New64(1, 6), New64(1, 7), New64(-22, 7), New64(1, 12), New64(5, 1), Zero()} {
+		actual, err := ParseDecimal(r.Text(10))
+		if assert.NoError(t, err) {
+			assertRationalEqual(t, r, actual)
+		}
+	}
+}
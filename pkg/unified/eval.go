@@ -0,0 +1,381 @@
+package unified
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/ripta/reals/pkg/constructive"
+)
+
+var (
+	ErrInvalidExpression = errors.New("invalid expression")
+	ErrDomain            = errors.New("argument out of domain")
+	ErrUndecidable       = errors.New("sign cannot be decided")
+)
+
+// evalSignPrecision is the precision at which the evaluators refine the sign
+// of an argument that constructive.SignExact cannot decide, such as e^-100.
+const evalSignPrecision = -4096
+
+// evalSign computes the sign of x. Numbers that are structurally rational
+// have an exact sign, including zero; others are compared against zero at a
+// precision of 2^-4096, beyond which ErrUndecidable is returned, rather than
+// assuming that x is zero.
+func evalSign(x *Real) (int, error) {
+	c := x.Constructive()
+	if sign, ok := constructive.SignExact(c); ok {
+		return sign, nil
+	}
+	if sign := constructive.PreciseSign(c, evalSignPrecision); sign != 0 {
+		return sign, nil
+	}
+
+	return 0, fmt.Errorf("%w: argument is indistinguishable from zero", ErrUndecidable)
+}
+
+// evalConstants are the named constants recognized by the evaluators.
+var evalConstants = map[string]func() *Real{
+	"pi":  Pi,
+	"e":   E,
+	"phi": Phi,
+}
+
+// evalFunctions are the functions of one argument recognized by the
+// evaluators.
+var evalFunctions = map[string]func(x *Real) (*Real, error){
+	"sqrt": func(x *Real) (*Real, error) {
+		sign, err := evalSign(x)
+		if err != nil {
+			return nil, err
+		}
+		if sign < 0 {
+			return nil, fmt.Errorf("%w: square root of a negative number", ErrDomain)
+		}
+		return New(constructive.Sqrt(x.Constructive()), nil), nil
+	},
+	"exp": func(x *Real) (*Real, error) {
+		return New(constructive.Exp(x.Constructive()), nil), nil
+	},
+	"ln": func(x *Real) (*Real, error) {
+		sign, err := evalSign(x)
+		if err != nil {
+			return nil, err
+		}
+		if sign <= 0 {
+			return nil, fmt.Errorf("%w: logarithm of a non-positive number", ErrDomain)
+		}
+		return New(constructive.Ln(x.Constructive()), nil), nil
+	},
+}
+
+// evalOperators are the binary operators recognized by the evaluators.
+var evalOperators = map[string]func(a, b *Real) (*Real, error){
+	"+": func(a, b *Real) (*Real, error) { return a.Add(b), nil },
+	"-": func(a, b *Real) (*Real, error) { return a.Subtract(b), nil },
+	"*": func(a, b *Real) (*Real, error) { return a.Multiply(b), nil },
+	"/": func(a, b *Real) (*Real, error) {
+		sign, err := evalSign(b)
+		if err != nil {
+			return nil, err
+		}
+		if sign == 0 {
+			return nil, constructive.ErrDivisionByZero
+		}
+		return a.Divide(b), nil
+	},
+}
+
+// EvalRPN evaluates a postfix (reverse Polish) expression, such as
+// []string{"1", "5", "sqrt", "+", "2", "/"} for φ. Each token is either a
+// number as accepted by ParseReal, one of the constants pi, e, and phi, one
+// of the binary operators + - * /, or one of the functions sqrt, exp, and
+// ln. The expression must leave exactly one value on the stack.
+func EvalRPN(tokens []string) (*Real, error) {
+	var stack []*Real
+	for idx, tok := range tokens {
+		if op, ok := evalOperators[tok]; ok {
+			if len(stack) < 2 {
+				return nil, fmt.Errorf("%w: token %d: %q needs two operands", ErrInvalidExpression, idx, tok)
+			}
+
+			a, b := stack[len(stack)-2], stack[len(stack)-1]
+			r, err := op(a, b)
+			if err != nil {
+				return nil, fmt.Errorf("token %d: %w", idx, err)
+			}
+			stack = append(stack[:len(stack)-2], r)
+			continue
+		}
+
+		if fn, ok := evalFunctions[tok]; ok {
+			if len(stack) < 1 {
+				return nil, fmt.Errorf("%w: token %d: %q needs an operand", ErrInvalidExpression, idx, tok)
+			}
+
+			r, err := fn(stack[len(stack)-1])
+			if err != nil {
+				return nil, fmt.Errorf("token %d: %w", idx, err)
+			}
+			stack[len(stack)-1] = r
+			continue
+		}
+
+		if c, ok := evalConstants[tok]; ok {
+			stack = append(stack, c())
+			continue
+		}
+
+		r, err := ParseReal(tok)
+		if err != nil {
+			return nil, fmt.Errorf("%w: token %d: %w", ErrInvalidExpression, idx, err)
+		}
+		stack = append(stack, r)
+	}
+
+	if len(stack) != 1 {
+		return nil, fmt.Errorf("%w: expected one value, found %d", ErrInvalidExpression, len(stack))
+	}
+	return stack[0], nil
+}
+
+// Eval parses and evaluates an infix expression, such as "(1+sqrt(5))/2",
+// "pi*e", or "2^10". Numbers, constants, and functions are those recognized
+// by EvalRPN, combined with the binary operators + - * / ^ and unary minus,
+// using the usual precedence; ^ binds tightest and is right-associative, so
+// that -2^2 is -4 and 2^3^2 is 512.
+//
+// Powers with an integer exponent are computed by repeated multiplication,
+// so that exact inputs stay exact; other powers are computed with
+// constructive.Pow. Division by zero and arguments outside of the domain of
+// a function are reported as errors, as are arguments whose sign is needed
+// but cannot be decided, with ErrUndecidable.
+func Eval(expr string) (*Real, error) {
+	tokens, err := tokenizeInfix(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &infixParser{tokens: tokens}
+	r, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok != "" {
+		return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidExpression, tok)
+	}
+
+	return r, nil
+}
+
+// tokenizeInfix splits expr into numbers, identifiers, and single-character
+// operators and parentheses.
+func tokenizeInfix(expr string) ([]string, error) {
+	isDigit := func(b byte) bool { return '0' <= b && b <= '9' || b == '.' }
+	isLetter := func(b byte) bool { return 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z' }
+
+	var tokens []string
+	for i := 0; i < len(expr); {
+		j := i + 1
+		switch b := expr[i]; {
+		case b == ' ' || b == '\t' || b == '\n':
+			i++
+			continue
+		case isDigit(b):
+			for j < len(expr) && isDigit(expr[j]) {
+				j++
+			}
+		case isLetter(b):
+			for j < len(expr) && isLetter(expr[j]) {
+				j++
+			}
+		case strings.IndexByte("+-*/^()", b) < 0:
+			return nil, fmt.Errorf("%w: unexpected character %q at offset %d", ErrInvalidExpression, b, i)
+		}
+
+		tokens = append(tokens, expr[i:j])
+		i = j
+	}
+
+	return tokens, nil
+}
+
+type infixParser struct {
+	tokens []string
+	pos    int
+}
+
+// peek returns the next token without consuming it, or the empty string at
+// the end of input.
+func (p *infixParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *infixParser) next() string {
+	tok := p.peek()
+	p.pos++
+	return tok
+}
+
+// parseSum parses terms separated by + and -.
+func (p *infixParser) parseSum() (*Real, error) {
+	return p.parseBinary(p.parseProduct, "+", "-")
+}
+
+// parseProduct parses factors separated by * and /.
+func (p *infixParser) parseProduct() (*Real, error) {
+	return p.parseBinary(p.parseUnary, "*", "/")
+}
+
+// parseBinary parses operands separated by any of the left-associative
+// operators ops.
+func (p *infixParser) parseBinary(operand func() (*Real, error), ops ...string) (*Real, error) {
+	r, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	for slices.Contains(ops, p.peek()) {
+		op := evalOperators[p.next()]
+		b, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		if r, err = op(r, b); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// parseUnary parses an optionally negated power.
+func (p *infixParser) parseUnary() (*Real, error) {
+	switch p.peek() {
+	case "-":
+		p.next()
+		r, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return r.Negate(), nil
+	case "+":
+		p.next()
+		return p.parseUnary()
+	}
+
+	return p.parsePower()
+}
+
+// parsePower parses an atom, optionally raised to a power. The exponent may
+// itself be negated, as in 2^-1.
+func (p *infixParser) parsePower() (*Real, error) {
+	base, err := p.parseAtom()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek() != "^" {
+		return base, nil
+	}
+
+	p.next()
+	exp, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return pow(base, exp)
+}
+
+// parseAtom parses a number, a constant, a function call, or a parenthesized
+// expression.
+func (p *infixParser) parseAtom() (*Real, error) {
+	tok := p.next()
+	switch {
+	case tok == "":
+		return nil, fmt.Errorf("%w: unexpected end of expression", ErrInvalidExpression)
+	case tok == "(":
+		r, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if tok := p.next(); tok != ")" {
+			return nil, fmt.Errorf("%w: expected \")\", found %q", ErrInvalidExpression, tok)
+		}
+		return r, nil
+	}
+
+	if c, ok := evalConstants[tok]; ok {
+		return c(), nil
+	}
+
+	if fn, ok := evalFunctions[tok]; ok {
+		if p.peek() != "(" {
+			return nil, fmt.Errorf("%w: expected \"(\" after %q", ErrInvalidExpression, tok)
+		}
+
+		arg, err := p.parseAtom()
+		if err != nil {
+			return nil, err
+		}
+		return fn(arg)
+	}
+
+	r, err := ParseReal(tok)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidExpression, err)
+	}
+	return r, nil
+}
+
+// pow computes base^exp. Integer exponents are computed exactly by repeated
+// squaring, inverting the base for negative exponents.
+func pow(base, exp *Real) (*Real, error) {
+	e, ok, _ := constructive.Identify(exp.Constructive())
+	if !ok || !e.IsInt() {
+		// only an exact zero takes this path, since a tiny base is valid
+		sign, err := evalSign(base)
+		if err != nil {
+			return nil, err
+		}
+		if sign == 0 {
+			sign, err := evalSign(exp)
+			if err != nil {
+				return nil, err
+			}
+			if sign > 0 {
+				return Zero(), nil
+			}
+			return nil, fmt.Errorf("%w: non-positive power of zero", ErrDomain)
+		}
+
+		r := constructive.Pow(base.Constructive(), exp.Constructive())
+		if r == nil {
+			return nil, fmt.Errorf("%w: power of a negative number", ErrDomain)
+		}
+		return New(r, nil), nil
+	}
+
+	n := new(big.Int).Set(e.Num())
+	if n.Sign() < 0 {
+		inv, err := evalOperators["/"](One(), base)
+		if err != nil {
+			return nil, err
+		}
+		base = inv
+		n.Neg(n)
+	}
+
+	r := One()
+	for i := n.BitLen() - 1; i >= 0; i-- {
+		r = r.Multiply(r)
+		if n.Bit(i) == 1 {
+			r = r.Multiply(base)
+		}
+	}
+	return r, nil
+}
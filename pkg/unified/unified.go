@@ -2,6 +2,7 @@ package unified
 
 import (
 	"fmt"
+	"math/big"
 
 	"github.com/ripta/reals/pkg/constructive"
 	"github.com/ripta/reals/pkg/rational"
@@ -37,12 +38,30 @@ func (u *Real) Constructive() constructive.Real {
 	return constructive.Multiply(u.cr, u.rr.Constructive())
 }
 
+// Factors returns the constructive and rational components of the unified
+// real number, whose product is the value being represented.
+func (u *Real) Factors() (constructive.Real, *rational.Number) {
+	return u.cr, u.rr
+}
+
+// Rational returns the exact rational value of the unified real number, or
+// nil if its constructive component is not one.
+func (u *Real) Rational() *rational.Number {
+	if !isOne(u.cr) {
+		return nil
+	}
+	return u.rr
+}
+
 // Add adds the current number and another number together, returning a new
 // Real number.
 func (u *Real) Add(other *Real) *Real {
-	if u.cr == other.cr {
+	if sameConstructive(u.cr, other.cr) {
 		return New(u.cr, u.rr.Add(other.rr))
 	}
+	if oppositeConstructive(u.cr, other.cr) {
+		return New(u.cr, u.rr.Subtract(other.rr))
+	}
 	if other.IsZero() {
 		return u
 	}
@@ -60,10 +79,10 @@ func (u *Real) Subtract(other *Real) *Real {
 // Multiply multiplies the current number by another number, returning a new
 // Real number.
 func (u *Real) Multiply(other *Real) *Real {
-	if u.cr == constructive.One() {
+	if isOne(u.cr) {
 		return New(other.cr, u.rr.Multiply(other.rr))
 	}
-	if other.cr == constructive.One() {
+	if isOne(other.cr) {
 		return New(u.cr, u.rr.Multiply(other.rr))
 	}
 
@@ -92,11 +111,34 @@ func (u *Real) ShiftRight(n int) *Real {
 	return New(u.cr, u.rr.ShiftRight(n))
 }
 
+// Scale multiplies the number by the integer n, returning a new Real number.
+// Only the rational component is scaled, so no constructive multiplication
+// is built.
+func (u *Real) Scale(n *big.Int) *Real {
+	return u.ScaleRat(rational.New(n, big.NewInt(1)))
+}
+
+// ScaleRat multiplies the number by the rational r, returning a new Real
+// number. Like Scale, only the rational component is scaled.
+func (u *Real) ScaleRat(r *rational.Number) *Real {
+	return New(u.cr, u.rr.Multiply(r))
+}
+
 // Negate returns the negation of the current number as a new Real number.
 func (u *Real) Negate() *Real {
 	return New(u.cr, u.rr.Negate())
 }
 
+// Abs returns the absolute value of the current number as a new Real number.
+// The absolute value is taken of each component separately, since
+// |cr * rr| = |cr| * |rr|.
+func (u *Real) Abs() *Real {
+	if isOne(u.cr) {
+		return New(u.cr, u.rr.Abs())
+	}
+	return New(constructive.Abs(u.cr), u.rr.Abs())
+}
+
 // Inverse returns the multiplicative inverse of the current number as a new
 // Real number.
 func (u *Real) Inverse() *Real {
@@ -130,7 +172,7 @@ func (u *Real) Format(f fmt.State, c rune) {
 		}
 
 	case 's', 'q':
-		if u.cr == constructive.One() {
+		if isOne(u.cr) {
 			fmt.Fprint(f, u.rr.String())
 			return
 		}
@@ -140,3 +182,26 @@ func (u *Real) Format(f fmt.State, c rune) {
 
 	fmt.Fprint(f, u.FormattedString(30, 10))
 }
+
+// sameConstructive reports whether a and b are the same constructive node,
+// ignoring any names attached to either.
+func sameConstructive(a, b constructive.Real) bool {
+	return constructive.Unwrap(a) == constructive.Unwrap(b)
+}
+
+// oppositeConstructive reports whether one of a and b is constructed as the
+// negation of the other, ignoring any names attached to either.
+func oppositeConstructive(a, b constructive.Real) bool {
+	if r, ok := constructive.NegationOperand(b); ok && sameConstructive(a, r) {
+		return true
+	}
+	if r, ok := constructive.NegationOperand(a); ok && sameConstructive(r, b) {
+		return true
+	}
+	return false
+}
+
+// isOne reports whether cr is the constructive one, even if it has been named.
+func isOne(cr constructive.Real) bool {
+	return sameConstructive(cr, constructive.One())
+}
@@ -0,0 +1,83 @@
+package unified
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ripta/reals/pkg/constructive"
+	"github.com/ripta/reals/pkg/rational"
+)
+
+var ErrInvalidReal = errors.New("invalid real")
+
+// FromFloat64 creates a Real number holding the exact value of f in its
+// rational component, or nil if f is NaN or infinite.
+func FromFloat64(f float64) *Real {
+	r := new(big.Rat).SetFloat64(f)
+	if r == nil {
+		return nil
+	}
+
+	return New(constructive.One(), rational.FromRational(r))
+}
+
+// ParseReal parses a string into an exact Real number. The string may be a
+// decimal as accepted by rational.ParseDecimal, such as "-3.14" or "0.1(6)",
+// or a fraction of two such decimals, such as "22/7".
+func ParseReal(s string) (*Real, error) {
+	num, denom, isFrac := strings.Cut(strings.TrimSpace(s), "/")
+
+	rn, err := rational.ParseDecimal(num)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidReal, err)
+	}
+	if !isFrac {
+		return New(constructive.One(), rn), nil
+	}
+
+	rd, err := rational.ParseDecimal(denom)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidReal, err)
+	}
+	if rd.IsZero() {
+		return nil, fmt.Errorf("%w: zero denominator in %q", ErrInvalidReal, s)
+	}
+
+	return New(constructive.One(), rn.Divide(rd)), nil
+}
+
+// ContinuedFraction64 computes the simple continued fraction [a₀; a₁, a₂, ...]
+// given by fracs. Since the result is always rational, it is computed exactly
+// as the last convergent, rather than as a constructive construction. The
+// terms after the first must be positive. An empty slice results in zero.
+func ContinuedFraction64(fracs []int64) *Real {
+	convs := rational.Convergents(fracs)
+	if len(convs) == 0 {
+		return Zero()
+	}
+
+	return New(constructive.One(), convs[len(convs)-1])
+}
+
+// FromDecimalStringSlice parses each string in ss exactly with ParseReal. It
+// stops at the first string that cannot be parsed, returning its error along
+// with its index.
+func FromDecimalStringSlice(ss []string) ([]*Real, error) {
+	reals := make([]*Real, len(ss))
+	for idx, s := range ss {
+		r, err := ParseReal(s)
+		if err != nil {
+			return nil, fmt.Errorf("index %d: %w", idx, err)
+		}
+		reals[idx] = r
+	}
+	return reals, nil
+}
+
+// DegreesToPiRadians converts an angle in degrees to radians, keeping the π
+// factor symbolic, so that the result is exactly π·degrees/180.
+func DegreesToPiRadians(degrees *rational.Number) *Real {
+	return New(constructive.Pi(), degrees.Multiply(rational.New64(1, 180)))
+}
@@ -1,6 +1,9 @@
 package unified
 
 import (
+	"fmt"
+	"math"
+	"math/big"
 	"testing"
 
 	"github.com/ripta/reals/pkg/constructive"
@@ -116,6 +119,16 @@ func TestConstructive(t *testing.T) {
 	}
 }
 
+func TestFactors(t *testing.T) {
+	cr, rr := New(constructive.Pi(), rational.New64(3, 4)).Factors()
+	assert.Same(t, constructive.Pi(), cr)
+	assert.Equal(t, 0, rr.Cmp(rational.New64(3, 4)))
+
+	cr, rr = New(nil, nil).Factors()
+	assert.Same(t, constructive.One(), cr)
+	assert.Equal(t, 0, rr.Cmp(rational.One()))
+}
+
 type addTest struct {
 	name     string
 	a        *Real
@@ -176,6 +189,19 @@ func TestAdd(t *testing.T) {
 		})
 	}
 
+	t.Run("negated constructive factor", func(t *testing.T) {
+		a := New(constructive.Pi(), rational.New64(3, 1))
+		b := New(constructive.Negate(constructive.Pi()), rational.One())
+		sum := a.Add(b)
+		assert.Same(t, constructive.Pi(), sum.cr)
+		assert.Equal(t, rational.New64(2, 1).String(), sum.rr.String())
+		assertEqualAtPrecision(t, New(constructive.Pi(), rational.New64(2, 1)), sum, -100)
+
+		sum = b.Add(a)
+		assert.Same(t, b.cr, sum.cr)
+		assertEqualAtPrecision(t, New(constructive.Pi(), rational.New64(2, 1)), sum, -100)
+	})
+
 	t.Run("commutativity property", func(t *testing.T) {
 		a := New(constructive.Pi(), rational.New64(2, 3))
 		b := New(constructive.E(), rational.New64(3, 5))
@@ -496,6 +522,19 @@ func TestShiftRight(t *testing.T) {
 	}
 }
 
+func TestScale(t *testing.T) {
+	scaled := Pi().ScaleRat(rational.New64(3, 4))
+	assertEqualAtPrecision(t, New(constructive.Pi(), rational.New64(3, 4)), scaled, -100)
+	assert.Same(t, constructive.Pi(), scaled.cr)
+
+	scaled = Pi().Scale(big.NewInt(-6))
+	assertEqualAtPrecision(t, New(constructive.Pi(), rational.New64(-6, 1)), scaled, -100)
+	assert.Same(t, constructive.Pi(), scaled.cr)
+
+	assert.Equal(t, "3/2", fmt.Sprintf("%s", Half().Scale(big.NewInt(3))))
+	assert.True(t, E().Scale(big.NewInt(0)).IsZero())
+}
+
 type negateTest struct {
 	name     string
 	input    *Real
@@ -545,6 +584,48 @@ func TestNegate(t *testing.T) {
 	})
 }
 
+type absTest struct {
+	name     string
+	input    *Real
+	expected *Real
+}
+
+var absTests = []absTest{
+	{
+		name:     "abs NegativeOne = One",
+		input:    NegativeOne(),
+		expected: One(),
+	},
+	{
+		name:     "abs Zero = Zero",
+		input:    Zero(),
+		expected: Zero(),
+	},
+	{
+		name:     "abs -Pi/2 = Pi/2",
+		input:    New(constructive.Pi(), rational.New64(-1, 2)),
+		expected: New(constructive.Pi(), rational.New64(1, 2)),
+	},
+	{
+		name:     "abs (-E * -3) = 3E",
+		input:    New(constructive.Negate(constructive.E()), rational.New64(-3, 1)),
+		expected: New(constructive.E(), rational.New64(3, 1)),
+	},
+}
+
+func TestAbs(t *testing.T) {
+	for _, test := range absTests {
+		t.Run(test.name, func(t *testing.T) {
+			result := test.input.Abs()
+			assertEqualAtPrecision(t, test.expected, result, -100)
+		})
+	}
+
+	t.Run("rational values stay rational", func(t *testing.T) {
+		assert.Equal(t, "1/2", fmt.Sprintf("%s", New(nil, rational.New64(-1, 2)).Abs()))
+	})
+}
+
 type inverseTest struct {
 	name     string
 	input    *Real
@@ -658,3 +739,226 @@ func TestIsZero(t *testing.T) {
 		})
 	}
 }
+
+func TestNamedConstructive(t *testing.T) {
+	half := New(constructive.Named("unit", constructive.One()), rational.New64(1, 2))
+	assert.Equal(t, rational.New64(1, 2).String(), fmt.Sprintf("%s", half))
+
+	// multiplying by a named one keeps the other constructive node as-is
+	product := half.Multiply(Pi())
+	assert.Same(t, constructive.Pi(), product.cr)
+
+	// adding values over the same, differently-named node adds the rationals
+	sum := New(constructive.Named("x", constructive.Pi()), rational.One()).Add(Pi())
+	assert.Equal(t, constructive.Unwrap(constructive.Pi()), constructive.Unwrap(sum.cr))
+	assertEqualAtPrecision(t, New(constructive.Pi(), rational.New64(2, 1)), sum, -100)
+}
+
+func TestFromFloat64(t *testing.T) {
+	assertEqualAtPrecision(t, Half(), FromFloat64(0.5), -100)
+	assertEqualAtPrecision(t, NegativeOne(), FromFloat64(-1), -100)
+	assertEqualAtPrecision(t, Zero(), FromFloat64(0), -100)
+
+	// the value is exact, so 0.1 is the nearest double rather than 1/10
+	assert.Equal(t, "3602879701896397/36028797018963968", fmt.Sprintf("%s", FromFloat64(0.1)))
+
+	assert.Nil(t, FromFloat64(math.NaN()))
+	assert.Nil(t, FromFloat64(math.Inf(-1)))
+}
+
+type parseRealTest struct {
+	input    string
+	expected *Real
+}
+
+var parseRealTests = []parseRealTest{
+	{"0.5", Half()},
+	{"-1", NegativeOne()},
+	{"10", Ten()},
+	{"0.1(6)", New(nil, rational.New64(1, 6))},
+	{"22/7", New(nil, rational.New64(22, 7))},
+	{" -1.5/0.25 ", New(nil, rational.New64(-6, 1))},
+}
+
+func TestParseReal(t *testing.T) {
+	for _, test := range parseRealTests {
+		t.Run(test.input, func(t *testing.T) {
+			result, err := ParseReal(test.input)
+			assert.NoError(t, err)
+			assertEqualAtPrecision(t, test.expected, result, -100)
+		})
+	}
+
+	for _, input := range []string{"", "abc", "1/", "1/0", "1/2/3", "0.(", "--1"} {
+		_, err := ParseReal(input)
+		assert.ErrorIs(t, err, ErrInvalidReal, input)
+	}
+}
+
+func TestRational(t *testing.T) {
+	assert.Equal(t, "3/4", New(nil, rational.New64(3, 4)).Rational().String())
+	assert.Equal(t, "-1", NegativeOne().Rational().String())
+	assert.Nil(t, Pi().Rational())
+}
+
+func TestContinuedFraction64(t *testing.T) {
+	cf := ContinuedFraction64([]int64{2, 1, 3, 4})
+	assert.Equal(t, "47/17", cf.Rational().String())
+	assertEqualAtPrecision(t, New(constructive.ContinuedFraction64([]int64{2, 1, 3, 4}), nil), cf, -100)
+
+	assert.Equal(t, "3", ContinuedFraction64([]int64{3}).Rational().String())
+	assert.True(t, ContinuedFraction64(nil).IsZero())
+}
+
+func TestFromDecimalStringSlice(t *testing.T) {
+	reals, err := FromDecimalStringSlice([]string{"0.1", "0.2"})
+	assert.NoError(t, err)
+	assert.Len(t, reals, 2)
+	assert.Equal(t, "3/10", reals[0].Add(reals[1]).Rational().String())
+
+	reals, err = FromDecimalStringSlice(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, reals)
+
+	_, err = FromDecimalStringSlice([]string{"1", "2.5", "x", "y"})
+	assert.ErrorIs(t, err, ErrInvalidReal)
+	assert.ErrorContains(t, err, "index 2")
+}
+
+func TestDegreesToPiRadians(t *testing.T) {
+	cr, rr := DegreesToPiRadians(rational.New64(180, 1)).Factors()
+	assert.Same(t, constructive.Pi(), cr)
+	assert.Equal(t, "1", rr.String())
+
+	_, rr = DegreesToPiRadians(rational.New64(-45, 1)).Factors()
+	assert.Equal(t, "-1/4", rr.String())
+
+	_, rr = DegreesToPiRadians(rational.New64(1, 2)).Factors()
+	assert.Equal(t, "1/360", rr.String())
+
+	assertEqualAtPrecision(t, New(constructive.Pi(), rational.One()), DegreesToPiRadians(rational.New64(180, 1)), -100)
+	assertEqualAtPrecision(t, New(constructive.DegreesToRadians(constructive.FromInt(30)), nil), DegreesToPiRadians(rational.New64(30, 1)), -100)
+}
+
+type evalRPNTest struct {
+	tokens   []string
+	expected *Real
+}
+
+var evalRPNTests = []evalRPNTest{
+	{[]string{"1", "5", "sqrt", "+", "2", "/"}, Phi()},
+	{[]string{"3", "4", "+"}, New(nil, rational.New64(7, 1))},
+	{[]string{"pi", "2", "/"}, New(constructive.Pi(), rational.New64(1, 2))},
+	{[]string{"1", "exp", "ln"}, One()},
+	{[]string{"e", "ln", "phi", "*", "phi", "-"}, Zero()},
+	{[]string{"0.1", "1/3", "-"}, New(nil, rational.New64(-7, 30))},
+	{[]string{"0", "100", "-", "exp", "ln"}, New(nil, rational.New64(-100, 1))},
+	{[]string{"1", "0", "100", "-", "exp", "/"}, New(constructive.Exp(constructive.FromInt(100)), nil)},
+}
+
+func TestEvalRPN(t *testing.T) {
+	for _, test := range evalRPNTests {
+		r, err := EvalRPN(test.tokens)
+		if assert.NoError(t, err, test.tokens) {
+			assertEqualAtPrecision(t, test.expected, r, -100)
+		}
+	}
+
+	// exact inputs stay exact
+	r, err := EvalRPN([]string{"0.1", "0.2", "+"})
+	assert.NoError(t, err)
+	assert.Equal(t, "3/10", r.Rational().String())
+}
+
+func TestEvalRPN_Invalid(t *testing.T) {
+	for _, tokens := range [][]string{nil, {"1", "+"}, {"sqrt"}, {"1", "2"}, {"x"}} {
+		_, err := EvalRPN(tokens)
+		assert.ErrorIs(t, err, ErrInvalidExpression, tokens)
+	}
+
+	_, err := EvalRPN([]string{"1", "0", "/"})
+	assert.ErrorIs(t, err, constructive.ErrDivisionByZero)
+	_, err = EvalRPN([]string{"1", "pi", "pi", "-", "/"})
+	assert.ErrorIs(t, err, constructive.ErrDivisionByZero)
+	_, err = EvalRPN([]string{"1", "2", "sqrt", "2", "sqrt", "*", "2", "-", "/"})
+	assert.ErrorIs(t, err, ErrUndecidable)
+	_, err = EvalRPN([]string{"0", "2", "-", "sqrt"})
+	assert.ErrorIs(t, err, ErrDomain)
+	_, err = EvalRPN([]string{"0", "ln"})
+	assert.ErrorIs(t, err, ErrDomain)
+}
+
+type evalTest struct {
+	expr     string
+	expected *Real
+}
+
+var evalTests = []evalTest{
+	{"(1+sqrt(5))/2", Phi()},
+	{"ln(e^3)", New(nil, rational.New64(3, 1))},
+	{"pi*e", New(constructive.Multiply(constructive.Pi(), constructive.E()), nil)},
+	{"2^10", New(nil, rational.New64(1024, 1))},
+	{"2^3^2", New(nil, rational.New64(512, 1))},
+	{"-2^2", New(nil, rational.New64(-4, 1))},
+	{"2^-2", New(nil, rational.New64(1, 4))},
+	{"1 - 2 - 3", New(nil, rational.New64(-4, 1))},
+	{"12 / 3 / 2", Two()},
+	{"1 + 2 * 3", New(nil, rational.New64(7, 1))},
+	{"(1 + 2) * 3", New(nil, rational.New64(9, 1))},
+	{"-(pi)", New(constructive.Pi(), rational.New64(-1, 1))},
+	{"2^0.5", Sqrt2()},
+	{"0^0.5", Zero()},
+	{"sqrt(2)^2", Two()},
+	{"exp(ln(phi))", Phi()},
+}
+
+func TestEval(t *testing.T) {
+	for _, test := range evalTests {
+		r, err := Eval(test.expr)
+		if assert.NoError(t, err, test.expr) {
+			assertEqualAtPrecision(t, test.expected, r, -100)
+		}
+	}
+
+	// arguments too small for SignExact to decide are still in the domain
+	tiny := New(constructive.Exp(constructive.FromInt(-100)), nil)
+	for expr, expected := range map[string]*Real{
+		"ln(exp(-100))":   New(nil, rational.New64(-100, 1)),
+		"1/exp(-100)":     tiny.Inverse(),
+		"sqrt(exp(-100))": New(constructive.Exp(constructive.FromInt(-50)), nil),
+		"exp(-100)^0.5":   New(constructive.Exp(constructive.FromInt(-50)), nil),
+		"exp(-100)^-0.5":  New(constructive.Exp(constructive.FromInt(50)), nil),
+	} {
+		r, err := Eval(expr)
+		if assert.NoError(t, err, expr) {
+			assertEqualAtPrecision(t, expected, r, -200)
+		}
+	}
+
+	// exact inputs stay exact
+	r, err := Eval("(0.1 + 0.2) ^ 2")
+	assert.NoError(t, err)
+	assert.Equal(t, "9/100", r.Rational().String())
+}
+
+func TestEval_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "1 +", "(1", "1)", "sqrt 2", "foo(1)", "2 pi", "1 % 2", "1..2"} {
+		_, err := Eval(expr)
+		assert.ErrorIs(t, err, ErrInvalidExpression, expr)
+	}
+
+	for _, expr := range []string{"1/0", "1/(pi-pi)", "0^-1"} {
+		_, err := Eval(expr)
+		assert.ErrorIs(t, err, constructive.ErrDivisionByZero, expr)
+	}
+
+	for _, expr := range []string{"1/(sqrt(2)^2-2)", "ln(sqrt(2)^2-2)", "sqrt(sqrt(2)^2-2)", "(sqrt(2)^2-2)^0.5"} {
+		_, err := Eval(expr)
+		assert.ErrorIs(t, err, ErrUndecidable, expr)
+	}
+
+	for _, expr := range []string{"sqrt(-1)", "ln(0)", "ln(-e)", "(-2)^0.5", "0^-0.5"} {
+		_, err := Eval(expr)
+		assert.ErrorIs(t, err, ErrDomain, expr)
+	}
+}